@@ -2,12 +2,9 @@ package main
 
 import (
 	"booking_system/cmd/providers"
-	"booking_system/cmd/providers/middelware"
-	"booking_system/internal/app/usecase"
 	"booking_system/internal/config"
-	"booking_system/internal/infrastructure/adapters/controllers"
-	"booking_system/internal/infrastructure/storage"
-	"log/slog"
+	"os"
+	_ "time/tzdata" // часовые пояса ресторанов не должны зависеть от zoneinfo в образе
 )
 
 func main() {
@@ -16,20 +13,26 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	dataBase, err := providers.NewDatabase(conf.DsnDatabase)
-	if err != nil {
-		log.Error("Failed to connect to database", err)
-		return
-	}
-	jwt := middelware.NewJwt(conf.TokenBot)
-	st := storage.New(log, dataBase.DataBase)
-	useCase := usecase.New(st, log, conf.TokenBot, jwt)
-	controller := controllers.New(log, useCase, jwt)
-
-	httpServer := providers.NewHTTPServer(conf.GetHttpPort(), conf.LogLevel, controller)
 
-	go func(httpServer *providers.HTTPServer, logger *slog.Logger, j *middelware.Jwt) {
-		httpServer.Run(logger, j)
-	}(httpServer, log, jwt)
-	select {}
+	command := "serve"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	switch command {
+	case "serve":
+		runServe(conf, log)
+	case "migrate":
+		if err := runMigrate(conf, log, os.Args[2:]); err != nil {
+			log.Error("Migration failed", "error", err)
+			os.Exit(1)
+		}
+	case "seed":
+		if err := runSeed(conf, log, os.Args[2:]); err != nil {
+			log.Error("Seed failed", "error", err)
+			os.Exit(1)
+		}
+	default:
+		log.Error("Unknown command. Available commands: serve, migrate, seed", "command", command)
+		os.Exit(2)
+	}
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"booking_system/cmd/providers"
+	"booking_system/internal/config"
+	"booking_system/internal/infrastructure/storage/migrations"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate up | down [N] | version | force VERSION"
+
+// runMigrate выполняет подкоманду migrate.
+func runMigrate(conf *config.Config, log *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	dataBase, err := providers.NewDatabase(conf.DsnDatabase)
+	if err != nil {
+		return err
+	}
+	defer dataBase.Close(context.Background())
+
+	sqlDB, err := dataBase.DataBase.DB()
+	if err != nil {
+		return err
+	}
+	migrator := migrations.New(log, sqlDB)
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.Force(version); err != nil {
+			return err
+		}
+	case "version":
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	version, dirty, err := migrator.Version(context.Background())
+	if err != nil {
+		return err
+	}
+	latest, err := migrations.LatestVersion()
+	if err != nil {
+		return err
+	}
+	log.Info("Schema version", "version", version, "dirty", dirty, "latest", latest)
+	return nil
+}
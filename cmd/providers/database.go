@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"context"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -20,3 +21,21 @@ func NewDatabase(dsn string) (*Database, error) {
 		DataBase: db,
 	}, nil
 }
+
+// Close закрывает пул соединений с базой данных.
+func (d *Database) Close(context.Context) error {
+	sqlDB, err := d.DataBase.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Ping проверяет доступность базы данных.
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DataBase.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	healthStatusOk   = "ok"
+	healthStatusFail = "fail"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
+// HealthCheck проверяет доступность зависимости сервиса.
+type HealthCheck func(ctx context.Context) error
+
+type dependencyStatus struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency"`
+}
+
+type readinessResponse struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]dependencyStatus `json:"dependencies"`
+}
+
+// AddReadinessCheck регистрирует проверку зависимости для /readyz.
+func (s *HTTPServer) AddReadinessCheck(name string, check HealthCheck) {
+	s.checksMu.Lock()
+	defer s.checksMu.Unlock()
+	s.readinessChecks[name] = check
+}
+
+// liveness сообщает, что процесс жив и обрабатывает запросы.
+func (s *HTTPServer) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": healthStatusOk})
+}
+
+// readiness опрашивает все зарегистрированные зависимости параллельно
+// и возвращает 503, если хотя бы одна из них недоступна.
+func (s *HTTPServer) readiness(c *gin.Context) {
+	s.checksMu.RLock()
+	checks := make(map[string]HealthCheck, len(s.readinessChecks))
+	for name, check := range s.readinessChecks {
+		checks[name] = check
+	}
+	s.checksMu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	result := readinessResponse{
+		Status:       healthStatusOk,
+		Dependencies: make(map[string]dependencyStatus, len(checks)),
+	}
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			started := time.Now()
+			err := check(ctx)
+			status := dependencyStatus{
+				Status:  healthStatusOk,
+				Latency: time.Since(started).String(),
+			}
+			if err != nil {
+				status.Status = healthStatusFail
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Dependencies[name] = status
+			if err != nil {
+				result.Status = healthStatusFail
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	if result.Status != healthStatusOk {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, result)
+}
@@ -3,11 +3,17 @@ package providers
 import (
 	"booking_system/cmd/providers/middelware"
 	"booking_system/internal/app/ports"
+	"booking_system/internal/infrastructure/adapters/metrics"
 	"booking_system/internal/infrastructure/adapters/routers"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"log/slog"
+	"net/http"
+	"sync"
 )
 
 type HTTPServer struct {
@@ -15,9 +21,15 @@ type HTTPServer struct {
 	Server      *gin.Engine
 	logLvl      string
 	controllers ports.IController
+	httpServer  *http.Server
+
+	checksMu        sync.RWMutex
+	readinessChecks map[string]HealthCheck
 }
 
-func NewHTTPServer(port int, logLvl string, controllers ports.IController) *HTTPServer {
+// NewHTTPServer создает сервер. X-Forwarded-For учитывается только от trustedProxies,
+// без них адрес клиента для ограничения частоты запросов берется из соединения.
+func NewHTTPServer(port int, logLvl string, serviceName string, trustedProxies []string, controllers ports.IController, m *metrics.Metrics) *HTTPServer {
 
 	switch logLvl {
 	case "debug":
@@ -31,21 +43,51 @@ func NewHTTPServer(port int, logLvl string, controllers ports.IController) *HTTP
 	}
 
 	server := gin.Default()
+	if err := server.SetTrustedProxies(trustedProxies); err != nil {
+		panic(err)
+	}
 	corsConfig := cors.Default()
-	server.Use(corsConfig)
-	return &HTTPServer{
+	server.Use(corsConfig, otelgin.Middleware(serviceName, otelgin.WithFilter(isTraced)), m.Middleware())
+	server.GET("/metrics", gin.WrapH(m.Handler()))
+	s := &HTTPServer{
 		port:        port,
 		Server:      server,
 		logLvl:      logLvl,
 		controllers: controllers,
+		httpServer: &http.Server{
+			Addr:    fmt.Sprintf(":%d", port),
+			Handler: server,
+		},
+		readinessChecks: make(map[string]HealthCheck),
 	}
+	server.GET("/healthz", s.liveness)
+	server.GET("/readyz", s.readiness)
+	return s
 }
 
-func (s *HTTPServer) Run(logger *slog.Logger, jwt *middelware.Jwt) error {
-	routers.New(s.Server, logger, s.controllers, jwt)
-	err := s.Server.Run(fmt.Sprintf(":%d", s.port))
-	if err != nil {
+// Run регистрирует роуты и блокируется до остановки сервера.
+// После вызова Shutdown возвращает nil.
+func (s *HTTPServer) Run(logger *slog.Logger, jwt *middelware.Jwt, timeouts routers.Timeouts, limits routers.RateLimits) error {
+	routers.New(s.Server, logger, s.controllers, jwt, timeouts, limits)
+	logger.Info("HTTP server started", "port", s.port)
+	err := s.httpServer.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
 	return nil
 }
+
+// Shutdown перестает принимать новые соединения и дожидается завершения
+// обрабатываемых запросов, но не дольше дедлайна ctx.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// isTraced исключает из трассировки служебные эндпоинты, которые опрашиваются оркестратором.
+func isTraced(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"booking_system/internal/infrastructure/adapters/metrics"
+	"booking_system/internal/infrastructure/adapters/tracing"
+	"context"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPServerTracesRequests(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewTracerProvider(exporter, "booking_system_test", 1)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	s := NewHTTPServer(0, "test", "booking_system", nil, nil, metrics.New())
+	s.Server.GET("/restaurants/:id", func(c *gin.Context) {
+		_, span := otel.Tracer("test").Start(c.Request.Context(), "Controller.GetRestaurant")
+		span.End()
+		c.Status(http.StatusOK)
+	})
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/restaurants/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	s.Server.ServeHTTP(httptest.NewRecorder(), req)
+	s.Server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush spans: %v", err)
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want server and handler spans only: %+v", len(spans), spans)
+	}
+
+	var server, handler tracetest.SpanStub
+	for _, span := range spans {
+		switch span.SpanKind {
+		case trace.SpanKindServer:
+			server = span
+		case trace.SpanKindInternal:
+			handler = span
+		}
+	}
+	if server.Name != "GET /restaurants/:id" {
+		t.Errorf("server span name = %q, want route template", server.Name)
+	}
+	if got := server.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("server span trace = %s, want trace from traceparent", got)
+	}
+	if got := server.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("server span parent = %s, want remote caller span", got)
+	}
+	if handler.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Errorf("handler span parent = %s, want server span %s", handler.Parent.SpanID(), server.SpanContext.SpanID())
+	}
+}
+
+func TestHTTPServerTrustsForwardedForOnlyFromProxies(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		want       string
+	}{
+		{name: "no proxies", remoteAddr: "203.0.113.7:4000", want: "203.0.113.7"},
+		{name: "trusted proxy", proxies: []string{"10.0.0.0/8"}, remoteAddr: "10.1.2.3:4000", want: "198.51.100.1"},
+		{name: "untrusted peer", proxies: []string{"10.0.0.0/8"}, remoteAddr: "203.0.113.7:4000", want: "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewHTTPServer(0, "test", "booking_system", tt.proxies, nil, metrics.New())
+			var got string
+			s.Server.GET("/ip", func(c *gin.Context) { got = c.ClientIP() })
+
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", "198.51.100.1")
+			s.Server.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("client IP = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
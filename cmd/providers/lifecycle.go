@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Lifecycle управляет фоновыми воркерами и порядком освобождения ресурсов приложения.
+type Lifecycle struct {
+	logger  *slog.Logger
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	closers []closer
+}
+
+type closer struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func NewLifecycle(logger *slog.Logger) *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Lifecycle{
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go запускает фоновый воркер. Контекст воркера отменяется при остановке приложения,
+// Shutdown дожидается его завершения.
+func (l *Lifecycle) Go(name string, worker func(ctx context.Context)) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		l.logger.Info("Background worker started", "worker", name)
+		worker(l.ctx)
+		l.logger.Info("Background worker stopped", "worker", name)
+	}()
+}
+
+// OnStop регистрирует функцию закрытия ресурса. Функции вызываются в порядке,
+// обратном порядку регистрации, после остановки всех воркеров.
+func (l *Lifecycle) OnStop(name string, fn func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closers = append(l.closers, closer{name: name, fn: fn})
+}
+
+// Shutdown останавливает воркеры и закрывает ресурсы, укладываясь в дедлайн ctx.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+
+	var errs []error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("background workers: %w", ctx.Err()))
+	}
+
+	l.mu.Lock()
+	closers := l.closers
+	l.mu.Unlock()
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		c := closers[i]
+		if err := c.fn(ctx); err != nil {
+			l.logger.Error("Failed to close resource", "resource", c.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+			continue
+		}
+		l.logger.Info("Resource closed", "resource", c.name)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,26 @@
+package providers
+
+import (
+	"booking_system/internal/app/ports"
+	"booking_system/internal/config"
+	"booking_system/internal/infrastructure/adapters/payments"
+	"fmt"
+)
+
+// NewPaymentProvider выбирает платежного провайдера по PAYMENT_PROVIDER. Значения по умолчанию
+// нет: поддельный провайдер подтверждает любой депозит через открытый вебхук и теряет платежи
+// при перезапуске, поэтому его нужно включать явно и только для локальной разработки.
+func NewPaymentProvider(conf *config.Config) (ports.IPaymentProvider, error) {
+	switch conf.PaymentProvider {
+	case "":
+		return nil, fmt.Errorf("PAYMENT_PROVIDER is required. Available providers: yookassa, fake (local development only)")
+	case "fake":
+		return payments.NewFake(conf.PaymentCurrency, conf.PaymentReturnURL), nil
+	case "yookassa":
+		if conf.YooKassaShopID == "" || conf.YooKassaSecret == "" {
+			return nil, fmt.Errorf("YOOKASSA_SHOP_ID and YOOKASSA_SECRET_KEY are required for the yookassa provider")
+		}
+		return payments.NewYooKassa(conf.YooKassaShopID, conf.YooKassaSecret, conf.PaymentCurrency, conf.PaymentReturnURL), nil
+	}
+	return nil, fmt.Errorf("unknown payment provider %q", conf.PaymentProvider)
+}
@@ -0,0 +1,50 @@
+package providers
+
+import (
+	"booking_system/internal/config"
+	"os"
+	"testing"
+)
+
+func TestNewPaymentProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     config.Config
+		wantName string
+		wantErr  bool
+	}{
+		{name: "not configured", conf: config.Config{}, wantErr: true},
+		{name: "unknown", conf: config.Config{PaymentProvider: "stripe"}, wantErr: true},
+		{name: "fake is explicit", conf: config.Config{PaymentProvider: "fake"}, wantName: "fake"},
+		{name: "yookassa without credentials", conf: config.Config{PaymentProvider: "yookassa"}, wantErr: true},
+		{name: "yookassa", conf: config.Config{PaymentProvider: "yookassa", YooKassaShopID: "shop", YooKassaSecret: "secret"}, wantName: "yookassa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewPaymentProvider(&tt.conf)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got provider %q, want error", provider.Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewPaymentProvider: %v", err)
+			}
+			if provider.Name() != tt.wantName {
+				t.Errorf("provider = %q, want %q", provider.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestPaymentProviderHasNoDefault(t *testing.T) {
+	// t.Setenv восстановит исходное значение после теста.
+	t.Setenv("PAYMENT_PROVIDER", "")
+	if err := os.Unsetenv("PAYMENT_PROVIDER"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPaymentProvider(config.NewConfig()); err == nil {
+		t.Fatal("empty PAYMENT_PROVIDER must be rejected")
+	}
+}
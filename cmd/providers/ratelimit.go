@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"booking_system/internal/app/ports"
+	"booking_system/internal/infrastructure/adapters/ratelimit"
+	"booking_system/internal/infrastructure/storage"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// NewRateLimiter выбирает хранилище корзин токенов по RATE_LIMIT_BACKEND.
+// Для "none" возвращает nil: ограничение частоты запросов отключено.
+func NewRateLimiter(backend string, db *gorm.DB) (ports.IRateLimiter, error) {
+	switch backend {
+	case "none":
+		return nil, nil
+	case "memory":
+		return ratelimit.NewMemory(), nil
+	case "postgres":
+		return storage.NewRateLimiter(db), nil
+	}
+	return nil, fmt.Errorf("unknown rate limit backend %q", backend)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"booking_system/cmd/providers"
+	"booking_system/internal/config"
+	"booking_system/internal/infrastructure/storage"
+	"booking_system/internal/infrastructure/storage/fixtures"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// runSeed загружает фикстуры из файлов, переданных аргументами.
+func runSeed(conf *config.Config, log *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: seed FILE [FILE...]")
+	}
+	dataBase, err := providers.NewDatabase(conf.DsnDatabase)
+	if err != nil {
+		return err
+	}
+	defer dataBase.Close(context.Background())
+
+	loader := fixtures.NewLoader(log, storage.New(log, dataBase.DataBase))
+	for _, path := range args {
+		fixture, err := fixtures.Load(path)
+		if err != nil {
+			return err
+		}
+		if err := loader.Apply(context.Background(), fixture); err != nil {
+			return err
+		}
+		log.Info("Fixture loaded", "file", path)
+	}
+	return nil
+}
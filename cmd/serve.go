@@ -0,0 +1,218 @@
+package main
+
+import (
+	"booking_system/cmd/providers"
+	"booking_system/cmd/providers/middelware"
+	"booking_system/internal/app/usecase"
+	"booking_system/internal/config"
+	"booking_system/internal/infrastructure/adapters/controllers"
+	"booking_system/internal/infrastructure/adapters/kafka"
+	"booking_system/internal/infrastructure/adapters/metrics"
+	"booking_system/internal/infrastructure/adapters/routers"
+	"booking_system/internal/infrastructure/adapters/tracing"
+	"booking_system/internal/infrastructure/storage"
+	"booking_system/internal/infrastructure/storage/migrations"
+	"context"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// runServe запускает HTTP-сервер и блокируется до получения сигнала остановки.
+func runServe(conf *config.Config, log *slog.Logger) {
+	dataBase, err := providers.NewDatabase(conf.DsnDatabase)
+	if err != nil {
+		log.Error("Failed to connect to database", "error", err)
+		return
+	}
+	sqlDB, err := dataBase.DataBase.DB()
+	if err != nil {
+		log.Error("Failed to get database connection pool", "error", err)
+		return
+	}
+	migrator := migrations.New(log, sqlDB)
+	if err := migrator.CheckVersion(context.Background()); err != nil {
+		log.Error("Database schema is not up to date, run `migrate up`", "error", err)
+		return
+	}
+	appMetrics := metrics.New()
+	if err := dataBase.DataBase.Use(appMetrics.GormPlugin()); err != nil {
+		log.Error("Failed to register database metrics", "error", err)
+		return
+	}
+	exporter, err := tracing.NewExporter(context.Background(), conf.TracingExporter, conf.OtlpEndpoint)
+	if err != nil {
+		log.Error("Failed to create tracing exporter", "error", err)
+		return
+	}
+	tracerProvider := tracing.NewTracerProvider(exporter, conf.ServiceName, conf.GetTracingRatio())
+	if err := dataBase.DataBase.Use(tracing.GormPlugin()); err != nil {
+		log.Error("Failed to register database tracing", "error", err)
+		return
+	}
+
+	lifecycle := providers.NewLifecycle(log)
+	lifecycle.OnStop("database", dataBase.Close)
+	lifecycle.OnStop("tracer provider", tracerProvider.Shutdown)
+
+	producer := kafka.New(log, conf.GetKafkaBrokers(), conf.KafkaTopic, conf.NameServiceKafka)
+	lifecycle.OnStop("kafka producer", producer.Close)
+
+	rateLimiter, err := providers.NewRateLimiter(conf.RateLimitBackend, dataBase.DataBase)
+	if err != nil {
+		log.Error("Failed to create rate limiter", "error", err)
+		return
+	}
+	paymentProvider, err := providers.NewPaymentProvider(conf)
+	if err != nil {
+		log.Error("Failed to create payment provider", "error", err)
+		return
+	}
+	if conf.PaymentProvider == "fake" {
+		log.Warn("Fake payment provider is enabled: deposits can be confirmed by anyone and are lost on restart, do not use it in production")
+	}
+
+	jwt := middelware.NewJwt(conf.TokenBot)
+	st := storage.New(log, dataBase.DataBase)
+	useCase := usecase.New(st, log, conf.TokenBot, jwt, appMetrics, producer, paymentProvider, conf.GetWaitlistOfferTTL())
+	controller := controllers.New(log, useCase, jwt)
+
+	lifecycle.Go("waitlist sweeper", func(ctx context.Context) {
+		ticker := time.NewTicker(conf.GetWaitlistSweepInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := useCase.ExpireWaitlist(ctx); err != nil {
+					log.Error("Failed to expire waitlist", "error", err)
+				}
+			}
+		}
+	})
+
+	lifecycle.Go("payment sweeper", func(ctx context.Context) {
+		ticker := time.NewTicker(conf.GetPaymentSweepInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := useCase.ExpirePayments(ctx); err != nil {
+					log.Error("Failed to expire payments", "error", err)
+				}
+			}
+		}
+	})
+
+	lifecycle.Go("approval sweeper", func(ctx context.Context) {
+		ticker := time.NewTicker(conf.GetApprovalSweepInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := useCase.ExpireEventApprovals(ctx); err != nil {
+					log.Error("Failed to expire event approvals", "error", err)
+				}
+			}
+		}
+	})
+
+	lifecycle.Go("idempotency sweeper", func(ctx context.Context) {
+		ticker := time.NewTicker(conf.GetIdempotencySweepInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := useCase.ExpireIdempotencyKeys(ctx); err != nil {
+					log.Error("Failed to expire idempotency keys", "error", err)
+				}
+			}
+		}
+	})
+
+	if retention := conf.GetReservationRetention(); retention > 0 {
+		lifecycle.Go("retention sweeper", func(ctx context.Context) {
+			ticker := time.NewTicker(conf.GetRetentionSweepInterval())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := useCase.ArchiveReservations(ctx, retention); err != nil {
+						log.Error("Failed to archive reservations", "error", err)
+					}
+				}
+			}
+		})
+	}
+
+	if rateLimiter != nil {
+		lifecycle.Go("rate limit sweeper", func(ctx context.Context) {
+			ticker := time.NewTicker(conf.GetRateLimitSweepInterval())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := rateLimiter.Cleanup(ctx); err != nil {
+						log.Error("Failed to clean up rate limit buckets", "error", err)
+					}
+				}
+			}
+		})
+	}
+
+	httpServer := providers.NewHTTPServer(conf.GetHttpPort(), conf.LogLevel, conf.ServiceName, conf.GetTrustedProxies(), controller, appMetrics)
+	httpServer.AddReadinessCheck("database", dataBase.Ping)
+	httpServer.AddReadinessCheck("kafka", producer.Ping)
+	httpServer.AddReadinessCheck("migrations", migrator.CheckVersion)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	timeouts := routers.Timeouts{
+		Default:  conf.GetRequestTimeout(),
+		PerRoute: conf.GetRouteTimeouts(),
+	}
+	limits := routers.RateLimits{
+		Limiter:  rateLimiter,
+		Default:  conf.GetRateLimitDefault(),
+		PerRoute: conf.GetRouteRateLimits(),
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- httpServer.Run(log, jwt, timeouts, limits)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Info("Shutdown signal received")
+	case err := <-serverErr:
+		if err != nil {
+			log.Error("HTTP server stopped unexpectedly", "error", err)
+		}
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), conf.GetShutdownTimeout())
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Error("Failed to shutdown HTTP server", "error", err)
+	}
+	if err := lifecycle.Shutdown(shutdownCtx); err != nil {
+		log.Error("Failed to shutdown application", "error", err)
+		return
+	}
+	log.Info("Application stopped")
+}
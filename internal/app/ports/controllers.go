@@ -6,7 +6,49 @@ type IController interface {
 	Authorize(*gin.Context)
 	GetUserBookings(*gin.Context)
 	UpdateBooking(*gin.Context)
+	// GetBooking бронь владельца с заголовком ETag
+	GetBooking(*gin.Context)
+	RescheduleBooking(*gin.Context)
 	CreateBooking(*gin.Context)
+	CreateBookingSeries(*gin.Context)
+	GetBookingSeries(*gin.Context)
+	CancelBookingSeries(*gin.Context)
 	GetBookingsDate(*gin.Context)
 	GetUserBookingsDate(*gin.Context)
+	GetBookingPolicy(*gin.Context)
+	UpdateBookingPolicy(*gin.Context)
+	GetSchedule(*gin.Context)
+	UpdateOpeningHours(*gin.Context)
+	SaveSpecialDay(*gin.Context)
+	DeleteSpecialDay(*gin.Context)
+	CreateClosure(*gin.Context)
+	DeleteClosure(*gin.Context)
+	CreateTableBlock(*gin.Context)
+	DeleteTableBlock(*gin.Context)
+	GetTableBlocks(*gin.Context)
+	DeleteTable(*gin.Context)
+	JoinWaitlist(*gin.Context)
+	GetUserWaitlist(*gin.Context)
+	LeaveWaitlist(*gin.Context)
+	ClaimWaitlistOffer(*gin.Context)
+	GetZones(*gin.Context)
+	CreateZone(*gin.Context)
+	UpdateZone(*gin.Context)
+	DeleteZone(*gin.Context)
+	BookZone(*gin.Context)
+	BookBuyout(*gin.Context)
+	GetPendingEvents(*gin.Context)
+	ApproveEvent(*gin.Context)
+	RejectEvent(*gin.Context)
+	SeatWalkIn(*gin.Context)
+	SeatReservation(*gin.Context)
+	MarkLeft(*gin.Context)
+	MarkNoShow(*gin.Context)
+	GetGuestReliability(*gin.Context)
+	// GetAuditLog журнал изменений броней, столиков и настроек ресторана для менеджера
+	GetAuditLog(*gin.Context)
+	// PaymentWebhook уведомления платежного провайдера, без JWT
+	PaymentWebhook(*gin.Context)
+	// HandleErrors middleware, переводящее ошибки обработчиков в HTTP-ответ
+	HandleErrors(*gin.Context)
 }
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// IEventPublisher публикует события сервиса во внешнюю шину. Ключ определяет
+// партицию, поэтому события одной сущности доставляются по порядку.
+type IEventPublisher interface {
+	Publish(ctx context.Context, key string, event interface{}) error
+}
@@ -0,0 +1,9 @@
+package ports
+
+// IMetrics собирает бизнес-показатели бронирований по ресторанам.
+// reason в ReservationFailed — код доменной ошибки или "internal".
+type IMetrics interface {
+	ReservationCreated(restaurantID string)
+	ReservationCanceled(restaurantID string)
+	ReservationFailed(restaurantID string, reason string)
+}
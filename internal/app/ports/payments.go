@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"booking_system/internal/domain"
+	"context"
+)
+
+// IPaymentProvider — платежный провайдер, принимающий депозиты за брони.
+type IPaymentProvider interface {
+	// Name идентификатор провайдера, сохраняется вместе с платежом
+	Name() string
+	// Currency валюта, в которой провайдер принимает платежи
+	Currency() string
+	// CreatePayment регистрирует платеж у провайдера. payment.ID служит ключом
+	// идемпотентности: повторный вызов с тем же ID не создает второй платеж
+	CreatePayment(ctx context.Context, payment domain.Payment, description string) (domain.PaymentIntent, error)
+	// Refund возвращает гостю оплаченный депозит полностью
+	Refund(ctx context.Context, payment domain.Payment) error
+	// ParseNotification разбирает и проверяет уведомление провайдера о смене статуса платежа.
+	// Неподписанное или нераспознанное уведомление — ErrInvalidNotification
+	ParseNotification(ctx context.Context, body []byte) (domain.PaymentNotification, error)
+}
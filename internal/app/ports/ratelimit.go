@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"time"
+)
+
+// IRateLimiter ограничивает частоту запросов клиентов корзиной токенов.
+type IRateLimiter interface {
+	// Allow забирает токен из корзины key. Если токенов нет, возвращает false
+	// и время, через которое можно повторить запрос
+	Allow(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error)
+	// Cleanup удаляет корзины, которые больше не нужно хранить, вызывается периодически
+	Cleanup(ctx context.Context) error
+}
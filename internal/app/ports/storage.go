@@ -2,29 +2,143 @@ package ports
 
 import (
 	"booking_system/internal/domain"
+	"context"
 	"time"
 )
 
 type IStorage interface {
-	GetTable(tableId string) (*domain.Table, error)
-	GetTablesByReservationID(reservationID string) ([]domain.Table, error)
-	IsTableAvailable(tableID string, startTime, endTime time.Time) (bool, error)
-	CreateUser(user domain.User) (domain.User, error)
+	GetTable(ctx context.Context, tableId string) (*domain.Table, error)
+	GetTablesByReservationID(ctx context.Context, reservationID string) ([]domain.Table, error)
+	IsTableAvailable(ctx context.Context, tableID string, startTime, endTime time.Time) (bool, error)
+	CreateUser(ctx context.Context, user domain.User) (domain.User, error)
 	//CheckUserForTelegram проверка существования пользвоателя по telegramId
-	CheckUserForTelegram(telegramId int64) (bool, domain.User, error)
+	CheckUserForTelegram(ctx context.Context, telegramId int64) (bool, domain.User, error)
 	// GetUserForId получение пользователя по Id
-	GetUserForId(user domain.User) (*domain.User, error)
+	GetUserForId(ctx context.Context, user domain.User) (*domain.User, error)
 	// GetReservationForId получение резервации (бронирования) по Id
-	GetReservationForId(id string) (*domain.Reservation, error)
-	// CreateReservation создание резервации(бронирования), возвращает id созданной резервации
-	CreateReservation(reservation *domain.Reservation, tableIDs map[string]string) (string, error)
+	GetReservationForId(ctx context.Context, id string) (*domain.Reservation, error)
+	// CreateReservation создание резервации(бронирования), возвращает id созданной резервации.
+	// maxActive > 0 — лимит действующих броней гостя в ресторане, проверяется в той же транзакции
+	CreateReservation(ctx context.Context, reservation *domain.Reservation, tableIDs map[string]string, maxActive int) (string, error)
 	// GetUserReservationsUser получение всех резерваций пользователя
-	GetUserReservationsUser(userId string) ([]*domain.Reservation, error)
-	// GetUserReservationsUserForDate получение всех резерваций пользователя на указанную дату
-	GetUserReservationsUserForDate(date time.Time, userID string) ([]*domain.Reservation, error)
-	// GetReservationsForDate получение всех резерваций на указанную дату
-	GetReservationsForDate(date time.Time) ([]*domain.Reservation, error)
-	// UpdateReservation обноваление резервации
-	UpdateReservation(reservation *domain.Reservation) (bool, error)
-	GetTablesWithAvailability(restaurantID string, dateTime time.Time) ([]domain.TableAvailability, error)
+	GetUserReservationsUser(ctx context.Context, userId string) ([]*domain.Reservation, error)
+	// GetUserReservationsUserForDate получение всех резерваций пользователя на указанную дату.
+	// День отсчитывается по часовому поясу ресторана брони
+	GetUserReservationsUserForDate(ctx context.Context, date time.Time, userID string) ([]*domain.Reservation, error)
+	// GetReservationsForDate получение всех резерваций на указанную дату по часовому поясу их ресторанов
+	GetReservationsForDate(ctx context.Context, date time.Time) ([]*domain.Reservation, error)
+	// UpdateReservation обноваление резервации, если ее версия не изменилась; false — бронь не найдена или устарела
+	UpdateReservation(ctx context.Context, reservation *domain.Reservation) (bool, error)
+	// UpdateReservationStatus условная смена статуса брони, если ее текущий статус равен from
+	UpdateReservationStatus(ctx context.Context, id string, from, to string) (bool, error)
+	// RescheduleReservation атомарный перенос брони на новое время и столики с проверкой их доступности
+	RescheduleReservation(ctx context.Context, reservation *domain.Reservation, tableIDs map[string]string, buffer time.Duration) error
+	GetTablesWithAvailability(ctx context.Context, restaurantID string, dateTime time.Time) ([]domain.TableAvailability, error)
+	// SaveRestaurant создает ресторан или обновляет существующий с тем же Id
+	SaveRestaurant(ctx context.Context, restaurant *domain.Restaurant) error
+	// SaveTable создает столик или обновляет существующий с тем же Id
+	SaveTable(ctx context.Context, table *domain.Table) error
+	// SaveUser создает пользователя или обновляет существующего с тем же Id
+	SaveUser(ctx context.Context, user *domain.User) error
+	// SaveReservation создает резервацию или обновляет существующую с тем же Id, заменяя набор столиков
+	SaveReservation(ctx context.Context, reservation *domain.Reservation, tableIDs map[string]string) error
+	// CreateReservationSeries создание серии повторяющихся броней
+	CreateReservationSeries(ctx context.Context, series *domain.ReservationSeries) error
+	// DeleteReservationSeries удаление серии, брони серии становятся разовыми
+	DeleteReservationSeries(ctx context.Context, id string) error
+	// GetReservationSeries получение серии по ID
+	GetReservationSeries(ctx context.Context, id string) (*domain.ReservationSeries, error)
+	// GetSeriesReservations получение броней серии в порядке времени
+	GetSeriesReservations(ctx context.Context, seriesID string) ([]*domain.Reservation, error)
+	// CancelSeriesReservations отмена еще не начавшихся броней серии, возвращает отмененные брони.
+	// Брони, до начала которых осталось меньше cutoff, отмечаются как поздно отмененные
+	CancelSeriesReservations(ctx context.Context, seriesID string, from time.Time, cutoff time.Duration) ([]*domain.Reservation, error)
+	// GetGuestReliability подсчет посещений, неявок и поздних отмен гостя по всем ресторанам
+	GetGuestReliability(ctx context.Context, userID string) (*domain.GuestReliability, error)
+	// HasGuestReservation есть ли у гостя хотя бы одна бронь в ресторане, включая архивные
+	HasGuestReservation(ctx context.Context, userID string, restaurantID string) (bool, error)
+	// SaveZone создание или замена зоны ресторана вместе с ее столиками
+	SaveZone(ctx context.Context, zone *domain.Zone) error
+	// GetZone получение зоны ресторана по ID
+	GetZone(ctx context.Context, restaurantID string, zoneID string) (*domain.Zone, error)
+	// GetZones получение всех зон ресторана
+	GetZones(ctx context.Context, restaurantID string) ([]domain.Zone, error)
+	// DeleteZone удаление зоны ресторана
+	DeleteZone(ctx context.Context, restaurantID string, zoneID string) (bool, error)
+	// GetPendingEventReservations получение броней зон и выкупов, ожидающих подтверждения менеджером
+	GetPendingEventReservations(ctx context.Context, restaurantID string) ([]*domain.Reservation, error)
+	// GetExpiredApprovals получение броней зон и выкупов, не подтвержденных менеджером в срок
+	GetExpiredApprovals(ctx context.Context, now time.Time) ([]*domain.Reservation, error)
+	// CreatePayment сохранение платежа за бронь
+	CreatePayment(ctx context.Context, payment *domain.Payment) error
+	// GetPaymentByExternalID получение платежа по ID у провайдера
+	GetPaymentByExternalID(ctx context.Context, provider string, externalID string) (*domain.Payment, error)
+	// GetReservationPayment получение последнего платежа брони, nil если депозит не требовался
+	GetReservationPayment(ctx context.Context, reservationID string) (*domain.Payment, error)
+	// UpdatePaymentStatus условная смена статуса платежа, если его текущий статус равен from
+	UpdatePaymentStatus(ctx context.Context, id string, from, to string) (bool, error)
+	// GetExpiredPayments получение неоплаченных платежей с истекшим сроком оплаты
+	GetExpiredPayments(ctx context.Context, now time.Time) ([]domain.Payment, error)
+	// AcquireIdempotencyKey занятие ключа идемпотентности, возвращает действующую запись, если ключ уже занят
+	AcquireIdempotencyKey(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+	// CompleteIdempotencyKey сохранение результата запроса с ключом идемпотентности
+	CompleteIdempotencyKey(ctx context.Context, userID string, key string, response []byte) error
+	// ReleaseIdempotencyKey освобождение ключа незавершенного запроса
+	ReleaseIdempotencyKey(ctx context.Context, userID string, key string) error
+	// DeleteExpiredIdempotencyKeys удаление ключей с истекшим сроком хранения
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+	// ArchiveReservations архивация броней, закончившихся раньше before, с удалением контактов гостя из броней
+	ArchiveReservations(ctx context.Context, before time.Time, now time.Time) (int64, error)
+	// GetRestaurant получение ресторана по ID
+	GetRestaurant(ctx context.Context, restaurantID string) (*domain.Restaurant, error)
+	// GetBookingPolicy получение правил бронирования ресторана, nil если правила не заданы
+	GetBookingPolicy(ctx context.Context, restaurantID string) (*domain.BookingPolicy, error)
+	// SaveBookingPolicy создание или замена правил бронирования ресторана
+	SaveBookingPolicy(ctx context.Context, policy *domain.BookingPolicy) error
+	// GetStaffRole получение роли пользователя в ресторане, пустая строка если он там не работает
+	GetStaffRole(ctx context.Context, restaurantID string, userID string) (string, error)
+	// SaveStaff назначение пользователя сотрудником ресторана
+	SaveStaff(ctx context.Context, staff *domain.RestaurantStaff) error
+	// GetSchedule получение часов работы, особых дней и закрытий ресторана на промежуток
+	GetSchedule(ctx context.Context, restaurantID string, from, to time.Time) (*domain.Schedule, error)
+	// ReplaceOpeningHours замена недельного расписания ресторана
+	ReplaceOpeningHours(ctx context.Context, restaurantID string, hours []domain.OpeningHours) error
+	// SaveSpecialDay создание или замена особого дня
+	SaveSpecialDay(ctx context.Context, day *domain.SpecialDay) error
+	// DeleteSpecialDay удаление особого дня
+	DeleteSpecialDay(ctx context.Context, restaurantID string, date time.Time) (bool, error)
+	// CreateClosure создание внепланового закрытия
+	CreateClosure(ctx context.Context, closure *domain.Closure) error
+	// DeleteClosure удаление внепланового закрытия, возвращает удаленное закрытие или nil, если его нет
+	DeleteClosure(ctx context.Context, restaurantID string, closureID string) (*domain.Closure, error)
+	// CreateTableBlock создание блокировки столика
+	CreateTableBlock(ctx context.Context, block *domain.TableBlock) error
+	// DeleteTableBlock снятие блокировки столика, возвращает снятую блокировку или nil, если ее нет
+	DeleteTableBlock(ctx context.Context, restaurantID string, blockID string) (*domain.TableBlock, error)
+	// GetTableBlocks получение блокировок столиков ресторана, пересекающихся с интервалом
+	GetTableBlocks(ctx context.Context, restaurantID string, from, to time.Time) ([]domain.TableBlock, error)
+	// GetRestaurantTables получение всех столиков ресторана
+	GetRestaurantTables(ctx context.Context, restaurantID string) ([]domain.Table, error)
+	// DeleteTable вывод столика из зала, возвращает удаленный столик или nil, если его нет
+	DeleteTable(ctx context.Context, restaurantID string, tableID string, now time.Time) (*domain.Table, error)
+	// CreateWaitlistEntry добавление гостя в лист ожидания
+	CreateWaitlistEntry(ctx context.Context, entry *domain.WaitlistEntry) error
+	// GetWaitlistEntry получение записи листа ожидания по ID
+	GetWaitlistEntry(ctx context.Context, id string) (*domain.WaitlistEntry, error)
+	// GetUserWaitlist получение записей пользователя в листах ожидания
+	GetUserWaitlist(ctx context.Context, userID string) ([]domain.WaitlistEntry, error)
+	// GetWaitingEntries получение ожидающих записей ресторана, пересекающихся с интервалом, в порядке очереди
+	GetWaitingEntries(ctx context.Context, restaurantID string, from, to time.Time) ([]domain.WaitlistEntry, error)
+	// GetExpiredOffers получение предложений листа ожидания с истекшим сроком
+	GetExpiredOffers(ctx context.Context, now time.Time) ([]domain.WaitlistEntry, error)
+	// ExpireStaleWaitlist закрытие ожидающих записей, время которых наступило
+	ExpireStaleWaitlist(ctx context.Context, now time.Time) (int64, error)
+	// UpdateWaitlistEntry условное обновление записи листа ожидания, если ее статус равен fromStatus
+	UpdateWaitlistEntry(ctx context.Context, entry *domain.WaitlistEntry, fromStatus string) (bool, error)
+	// ClaimWaitlistOffer атомарное принятие предложения листа ожидания: создание брони и закрытие записи
+	ClaimWaitlistOffer(ctx context.Context, entry *domain.WaitlistEntry, reservation *domain.Reservation, tableIDs map[string]string, buffer time.Duration, maxActive int) error
+	// AppendAuditEntry добавление записи в журнал аудита
+	AppendAuditEntry(ctx context.Context, entry *domain.AuditEntry) error
+	// GetAuditLog получение записей журнала аудита ресторана, начиная с самых новых
+	GetAuditLog(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
 }
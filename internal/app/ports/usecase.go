@@ -2,17 +2,82 @@ package ports
 
 import (
 	"booking_system/internal/dto"
+	"context"
 	"time"
 )
 
 type IUseCase interface {
-	AuthUser(dto dto.UserDTO) (dto.UserDTO, string, error)
-	GetReservationForDate(date *time.Time) ([]dto.ReservationDTO, error)
-	CreateReservation(dto dto.ReservationDTO) (dto.ReservationDTO, error)
-	GetUserReservations(userId string) ([]dto.ReservationDTO, error)
-	GetUserReservationsDate(date *time.Time, userId string) ([]dto.ReservationDTO, error)
-	ValidateTelegramHash(telegramHash string, data map[string]string) (bool, error)
-	GetReservationForId(reservationId string) (dto.ReservationDTO, error)
-	UpdateReservation(dto dto.ReservationDTO) (bool, error)
-	GetTableForReservationDate(date time.Time, restaurantId string) ([]dto.AvaibleTableDTO, error)
+	AuthUser(ctx context.Context, dto dto.UserDTO) (dto.UserDTO, string, error)
+	GetReservationForDate(ctx context.Context, date *time.Time) ([]dto.ReservationDTO, error)
+	CreateReservation(ctx context.Context, dto dto.ReservationDTO) (dto.ReservationDTO, error)
+	// CreateReservationIdempotent создание брони с ключом идемпотентности; replayed — результат взят из первого запроса
+	CreateReservationIdempotent(ctx context.Context, key string, dto dto.ReservationDTO) (dto.ReservationDTO, bool, error)
+	// ExpireIdempotencyKeys удаление просроченных ключей идемпотентности, вызывается периодически
+	ExpireIdempotencyKeys(ctx context.Context) error
+	// ArchiveReservations архивация броней старше retention, вызывается периодически
+	ArchiveReservations(ctx context.Context, retention time.Duration) error
+	GetUserReservations(ctx context.Context, userId string) ([]dto.ReservationDTO, error)
+	GetUserReservationsDate(ctx context.Context, date *time.Time, userId string) ([]dto.ReservationDTO, error)
+	ValidateTelegramHash(ctx context.Context, telegramHash string, data map[string]string) (bool, error)
+	GetReservationForId(ctx context.Context, reservationId string) (dto.ReservationDTO, error)
+	// UpdateReservation изменение контактов и числа гостей брони с проверкой версии: ErrReservationModified, если бронь изменили после чтения
+	UpdateReservation(ctx context.Context, dto dto.ReservationDTO) (dto.ReservationDTO, error)
+	// CancelReservation отмена брони гостем: после начала брони отмена запрещена, поздняя отмена отмечается
+	CancelReservation(ctx context.Context, userId string, reservationId string) (dto.ReservationDTO, error)
+	// CreateReservationSeries создание серии броней по правилу повторения; занятые повторения попадают в Conflicts
+	CreateReservationSeries(ctx context.Context, reservation dto.ReservationDTO, recurrence dto.RecurrenceDTO) (dto.ReservationSeriesDTO, error)
+	GetReservationSeries(ctx context.Context, userId string, seriesId string) (dto.ReservationSeriesDTO, error)
+	// CancelReservationSeries отмена всех еще не начавшихся броней серии
+	CancelReservationSeries(ctx context.Context, userId string, seriesId string) (dto.ReservationSeriesDTO, error)
+	// RescheduleReservation перенос брони пользователя на другое время и/или столики
+	RescheduleReservation(ctx context.Context, userId string, reschedule dto.RescheduleDTO) (dto.ReservationDTO, error)
+	// GetTableForReservationDate доступность столиков на момент date, заданный по местному времени ресторана
+	GetTableForReservationDate(ctx context.Context, date time.Time, restaurantId string) ([]dto.AvaibleTableDTO, error)
+	GetBookingPolicy(ctx context.Context, restaurantId string) (dto.BookingPolicyDTO, error)
+	UpdateBookingPolicy(ctx context.Context, userId string, dto dto.BookingPolicyDTO) (dto.BookingPolicyDTO, error)
+	// GetSchedule расписание ресторана на дни [from, to), заданные по местному времени ресторана
+	GetSchedule(ctx context.Context, restaurantId string, from, to time.Time) (dto.ScheduleDTO, error)
+	UpdateOpeningHours(ctx context.Context, userId string, restaurantId string, hours []dto.OpeningHoursDTO) error
+	SaveSpecialDay(ctx context.Context, userId string, day dto.SpecialDayDTO) error
+	DeleteSpecialDay(ctx context.Context, userId string, restaurantId string, date time.Time) error
+	CreateClosure(ctx context.Context, userId string, closure dto.ClosureDTO) (dto.ClosureDTO, error)
+	DeleteClosure(ctx context.Context, userId string, restaurantId string, closureId string) error
+	CreateTableBlock(ctx context.Context, userId string, block dto.TableBlockDTO) (dto.TableBlockDTO, error)
+	DeleteTableBlock(ctx context.Context, userId string, restaurantId string, blockId string) error
+	// GetTableBlocks блокировки столиков на дни [from, to), заданные по местному времени ресторана
+	GetTableBlocks(ctx context.Context, userId string, restaurantId string, from, to time.Time) ([]dto.TableBlockDTO, error)
+	// DeleteTable вывод столика из зала; брони, в которых он был, остаются в истории
+	DeleteTable(ctx context.Context, userId string, restaurantId string, tableId string) error
+	JoinWaitlist(ctx context.Context, entry dto.WaitlistEntryDTO) (dto.WaitlistEntryDTO, error)
+	GetUserWaitlist(ctx context.Context, userId string) ([]dto.WaitlistEntryDTO, error)
+	LeaveWaitlist(ctx context.Context, userId string, entryId string) error
+	ClaimWaitlistOffer(ctx context.Context, userId string, entryId string) (dto.ReservationDTO, error)
+	// ExpireWaitlist закрывает просроченные записи и предложения листа ожидания, вызывается периодически
+	ExpireWaitlist(ctx context.Context) error
+	GetZones(ctx context.Context, restaurantId string) ([]dto.ZoneDTO, error)
+	// SaveZone создание зоны без ID или замена существующей, userId — менеджер ресторана
+	SaveZone(ctx context.Context, userId string, zone dto.ZoneDTO) (dto.ZoneDTO, error)
+	DeleteZone(ctx context.Context, userId string, restaurantId string, zoneId string) error
+	// BookEvent бронь зоны целиком или выкуп ресторана, если ZoneID пуст
+	BookEvent(ctx context.Context, event dto.EventBookingDTO) (dto.ReservationDTO, error)
+	// GetPendingEvents брони зон и выкупы, ожидающие подтверждения менеджером
+	GetPendingEvents(ctx context.Context, userId string, restaurantId string) ([]dto.ReservationDTO, error)
+	DecideEvent(ctx context.Context, userId string, reservationId string, approve bool) (dto.ReservationDTO, error)
+	// SeatWalkIn сажает гостей без брони, userId — менеджер или хост ресторана
+	SeatWalkIn(ctx context.Context, userId string, walkIn dto.WalkInDTO) (dto.ReservationDTO, error)
+	SeatReservation(ctx context.Context, userId string, reservationId string) (dto.ReservationDTO, error)
+	// HandlePaymentNotification обработка уведомления платежного провайдера о депозите
+	HandlePaymentNotification(ctx context.Context, body []byte) error
+	// ExpirePayments отмена броней с просроченной оплатой депозита, вызывается периодически
+	ExpirePayments(ctx context.Context) error
+	// ExpireEventApprovals отмена броней зон и выкупов, не подтвержденных менеджером в срок, вызывается периодически
+	ExpireEventApprovals(ctx context.Context) error
+	// MarkLeft отмечает уход гостей и освобождает столики
+	MarkLeft(ctx context.Context, userId string, reservationId string) (dto.ReservationDTO, error)
+	// MarkNoShow отмечает неявку гостя после начала брони
+	MarkNoShow(ctx context.Context, userId string, reservationId string) (dto.ReservationDTO, error)
+	// GetGuestReliability надежность гостя guestId, доступна персоналу ресторана
+	GetGuestReliability(ctx context.Context, userId string, restaurantId string, guestId string) (dto.GuestReliabilityDTO, error)
+	// GetAuditLog журнал аудита ресторана, начиная с самых новых записей; доступен менеджеру
+	GetAuditLog(ctx context.Context, userId string, filter dto.AuditFilterDTO) ([]dto.AuditEntryDTO, error)
 }
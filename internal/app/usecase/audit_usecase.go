@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GetAuditLog показывает менеджеру, кто, когда и как менял брони, столики и настройки ресторана.
+func (u UserService) GetAuditLog(ctx context.Context, userId string, filterDto dto.AuditFilterDTO) (_ []dto.AuditEntryDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetAuditLog")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, filterDto.RestaurantID, userId); err != nil {
+		return nil, err
+	}
+	filter := toAuditFilterDomain(&filterDto)
+	if filter.Limit <= 0 || filter.Limit > domain.AuditMaxLimit {
+		filter.Limit = domain.AuditDefaultLimit
+	}
+	entries, err := u.storage.GetAuditLog(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.AuditEntryDTO, 0, len(entries))
+	for i := range entries {
+		result = append(result, *fromAuditEntryDomain(&entries[i]))
+	}
+	return result, nil
+}
+
+// audit дописывает в журнал разницу между снимками сущности before и after. Контакты гостя
+// в журнал не попадают, записывается только факт их изменения. Изменение к этому моменту
+// уже сохранено, поэтому ошибка записи журнала только логируется.
+func (u UserService) audit(ctx context.Context, entry domain.AuditEntry, before, after any) {
+	changes, err := domain.AuditDiff(before, after)
+	if err != nil {
+		u.logger.Error("Failed to diff audited entity", "entity_type", entry.EntityType, "entity_id", entry.EntityID, "error", err)
+		return
+	}
+	if len(changes) == 0 && entry.Action == domain.AuditUpdate {
+		return
+	}
+	domain.RedactAuditChanges(changes)
+	entry.ID = uuid.New().String()
+	entry.RequestID = domain.RequestID(ctx)
+	entry.Changes = changes
+	entry.CreatedAt = time.Now()
+	if err = u.storage.AppendAuditEntry(ctx, &entry); err != nil {
+		u.logger.Error("Failed to append audit entry", "entity_type", entry.EntityType, "entity_id", entry.EntityID, "action", entry.Action, "error", err)
+	}
+}
+
+// auditReservation записывает в журнал изменение брони. before == nil — бронь создана.
+func (u UserService) auditReservation(ctx context.Context, actorId string, action string, before, after *domain.Reservation) {
+	entry := domain.AuditEntry{
+		RestaurantID: after.RestaurantID,
+		EntityType:   domain.AuditEntityReservation,
+		EntityID:     after.ID,
+		Action:       action,
+		ActorID:      actorId,
+	}
+	if before == nil {
+		u.audit(ctx, entry, nil, fromReservationDomain(after))
+		return
+	}
+	u.audit(ctx, entry, fromReservationDomain(before), fromReservationDomain(after))
+}
+
+// auditStatusChange записывает в журнал смену статуса брони с from на текущий,
+// сделанную через UpdateReservationStatus. reservation загружена уже после смены.
+func (u UserService) auditStatusChange(ctx context.Context, actorId string, action string, reservation *domain.Reservation, from string) {
+	before := *reservation
+	before.Status = from
+	u.auditReservation(ctx, actorId, action, &before, reservation)
+}
+
+// auditRestaurant записывает в журнал изменение настроек ресторана.
+func (u UserService) auditRestaurant(ctx context.Context, actorId string, action string, restaurantId string, before, after any) {
+	u.audit(ctx, domain.AuditEntry{
+		RestaurantID: restaurantId,
+		EntityType:   domain.AuditEntityRestaurant,
+		EntityID:     restaurantId,
+		Action:       action,
+		ActorID:      actorId,
+	}, before, after)
+}
+
+// auditKeyed оборачивает снимок вложенного объекта ресторана в ключ "collection.id", чтобы
+// в журнале было видно, какой именно объект изменился, даже если его ID совпадает до и после.
+func auditKeyed(collection string, id string, value any) map[string]any {
+	return map[string]any{collection + "." + id: value}
+}
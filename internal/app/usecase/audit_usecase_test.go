@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditReservationRecordsOnlyThatContactsChanged(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	created, err := service.CreateReservation(context.Background(), dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "t1"}},
+		Capacity:     2,
+		Contacts:     dto.ContactsDTO{Name: "Anna", Phone: "+79990000001"},
+	})
+	if err != nil {
+		t.Fatalf("CreateReservation: %v", err)
+	}
+
+	entries := storage.auditEntries(created.ID)
+	if len(entries) != 1 || entries[0].Action != domain.AuditCreate {
+		t.Fatalf("audit entries = %+v, want one create", entries)
+	}
+	contacts, ok := entries[0].Changes["contacts"]
+	if !ok || string(contacts.To) != string(domain.AuditRedacted) {
+		t.Errorf("contacts change = %s, want %s", contacts.To, domain.AuditRedacted)
+	}
+	data, err := json.Marshal(entries[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Anna") || strings.Contains(string(data), "+7999") {
+		t.Errorf("audit entry leaks guest contacts: %s", data)
+	}
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (u UserService) CreateTableBlock(ctx context.Context, userId string, blockDto dto.TableBlockDTO) (_ dto.TableBlockDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateTableBlock")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, blockDto.RestaurantID, userId); err != nil {
+		return blockDto, err
+	}
+	table, err := u.storage.GetTable(ctx, blockDto.TableID)
+	if err != nil {
+		return blockDto, err
+	}
+	if table.RestaurantID != blockDto.RestaurantID {
+		return blockDto, domain.ErrTableNotFound.Withf("table %s not found in restaurant %s", blockDto.TableID, blockDto.RestaurantID)
+	}
+
+	block := toTableBlockDomain(&blockDto)
+	block.ID = uuid.New().String()
+	block.CreatedBy = userId
+	block.CreatedAt = time.Now()
+	if err = u.storage.CreateTableBlock(ctx, block); err != nil {
+		return blockDto, err
+	}
+	result := fromTableBlockDomain(block)
+	u.auditTableBlock(ctx, userId, domain.AuditBlock, block, nil, auditKeyed("blocks", block.ID, result))
+	u.logger.Info("Table blocked", "table_id", block.TableID, "block_id", block.ID, "user_id", userId)
+	return *result, nil
+}
+
+func (u UserService) DeleteTableBlock(ctx context.Context, userId string, restaurantId string, blockId string) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteTableBlock")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return err
+	}
+	block, err := u.storage.DeleteTableBlock(ctx, restaurantId, blockId)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return domain.ErrTableBlockNotFound
+	}
+	u.auditTableBlock(ctx, userId, domain.AuditUnblock, block, auditKeyed("blocks", block.ID, fromTableBlockDomain(block)), nil)
+	u.logger.Info("Table block removed", "block_id", blockId, "user_id", userId)
+	return nil
+}
+
+func (u UserService) GetTableBlocks(ctx context.Context, userId string, restaurantId string, from, to time.Time) (_ []dto.TableBlockDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetTableBlocks")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return nil, err
+	}
+	loc, err := u.restaurantLocation(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := u.storage.GetTableBlocks(ctx, restaurantId, wallClock(from, loc), wallClock(to, loc))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.TableBlockDTO, 0, len(blocks))
+	for _, b := range blocks {
+		result = append(result, *fromTableBlockDomain(&b))
+	}
+	return result, nil
+}
+
+// DeleteTable выводит столик из зала. Столик с предстоящими бронями удалить нельзя:
+// их нужно сначала перенести или отменить.
+func (u UserService) DeleteTable(ctx context.Context, userId string, restaurantId string, tableId string) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteTable")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return err
+	}
+	table, err := u.storage.DeleteTable(ctx, restaurantId, tableId, time.Now())
+	if err != nil {
+		return err
+	}
+	if table == nil {
+		return domain.ErrTableNotFound.Withf("table %s not found in restaurant %s", tableId, restaurantId)
+	}
+	u.audit(ctx, domain.AuditEntry{
+		RestaurantID: restaurantId,
+		EntityType:   domain.AuditEntityTable,
+		EntityID:     table.ID,
+		Action:       domain.AuditDelete,
+		ActorID:      userId,
+	}, fromTableDomain(table), nil)
+	u.logger.Info("Table deleted", "table_id", tableId, "restaurant_id", restaurantId, "user_id", userId)
+	return nil
+}
+
+// auditTableBlock записывает в журнал блокировку столика или ее снятие.
+func (u UserService) auditTableBlock(ctx context.Context, userId string, action string, block *domain.TableBlock, before, after any) {
+	u.audit(ctx, domain.AuditEntry{
+		RestaurantID: block.RestaurantID,
+		EntityType:   domain.AuditEntityTable,
+		EntityID:     block.TableID,
+		Action:       action,
+		ActorID:      userId,
+	}, before, after)
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"time"
+)
+
+// CancelReservation отменяет бронь гостя по правилам отмены ресторана.
+func (u UserService) CancelReservation(ctx context.Context, userId string, reservationId string) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CancelReservation")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.storage.GetReservationForId(ctx, reservationId)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if reservation == nil {
+		return dto.ReservationDTO{}, domain.ErrReservationNotFound
+	}
+	if reservation.UserID != userId {
+		return dto.ReservationDTO{}, domain.ErrReservationForbidden
+	}
+	policy, err := u.bookingPolicy(ctx, reservation.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	before := *reservation
+	if err = reservation.Cancel(policy, time.Now()); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = u.saveReservation(ctx, reservation); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.auditReservation(ctx, userId, domain.AuditCancel, &before, reservation)
+	u.metrics.ReservationCanceled(reservation.RestaurantID)
+	u.logger.Info("Reservation canceled", "reservation_id", reservation.ID, "late", reservation.LateCancel, "user_id", userId)
+	u.settleDeposit(ctx, reservation)
+	u.offerFreedSlot(ctx, reservation.RestaurantID, reservation.StartTime, reservation.EndTime)
+	return u.reservationWithTables(ctx, reservation)
+}
+
+// GetGuestReliability показывает персоналу ресторана, насколько гость надежен.
+// Историю видно только по гостям, которые бронировали столик в этом ресторане.
+func (u UserService) GetGuestReliability(ctx context.Context, userId string, restaurantId string, guestId string) (_ dto.GuestReliabilityDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetGuestReliability")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireStaff(ctx, restaurantId, userId); err != nil {
+		return dto.GuestReliabilityDTO{}, err
+	}
+	known, err := u.storage.HasGuestReservation(ctx, guestId, restaurantId)
+	if err != nil {
+		return dto.GuestReliabilityDTO{}, err
+	}
+	if !known {
+		return dto.GuestReliabilityDTO{}, domain.ErrGuestNotFound
+	}
+	reliability, err := u.storage.GetGuestReliability(ctx, guestId)
+	if err != nil {
+		return dto.GuestReliabilityDTO{}, err
+	}
+	return *fromGuestReliabilityDomain(reliability), nil
+}
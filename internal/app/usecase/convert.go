@@ -3,6 +3,7 @@ package usecase
 import (
 	"booking_system/internal/domain"
 	"booking_system/internal/dto"
+	"time"
 )
 
 // ToUserDomain преобразует структуру UserDTO в User.
@@ -51,7 +52,23 @@ func toReservationDomain(dto *dto.ReservationDTO) (*domain.Reservation, []*domai
 			Name:  dto.Contacts.Name,
 			Phone: dto.Contacts.Phone,
 		},
-		Capacity: dto.Capacity,
+		Capacity:   dto.Capacity,
+		Source:     dto.Source,
+		SeriesID:   dto.SeriesID,
+		Scope:      dto.Scope,
+		ZoneID:     dto.ZoneID,
+		LateCancel: dto.LateCancel,
+		NoShow:     dto.NoShow,
+		Version:    dto.Version,
+	}
+	if dto.SeatedAt != nil {
+		reservation.SeatedAt = *dto.SeatedAt
+	}
+	if dto.LeftAt != nil {
+		reservation.LeftAt = *dto.LeftAt
+	}
+	if dto.CanceledAt != nil {
+		reservation.CanceledAt = *dto.CanceledAt
 	}
 	tables := make([]*domain.Table, 0, len(dto.Table))
 	for _, table := range dto.Table {
@@ -106,7 +123,7 @@ func fromTableDomain(domain *domain.Table) *dto.TableDTO {
 
 // FromReservationDomain преобразует структуру Reservation в ReservationDTO.
 func fromReservationDomain(domain *domain.Reservation) *dto.ReservationDTO {
-	return &dto.ReservationDTO{
+	reservation := &dto.ReservationDTO{
 		ID:           domain.ID,
 		UserID:       domain.UserID,
 		RestaurantID: domain.RestaurantID,
@@ -118,8 +135,32 @@ func fromReservationDomain(domain *domain.Reservation) *dto.ReservationDTO {
 			Name:  domain.Contacts.Name,
 			Phone: domain.Contacts.Phone,
 		},
-		Table: make([]dto.TableDTO, 0),
+		Table:      make([]dto.TableDTO, 0),
+		Source:     domain.Source,
+		SeriesID:   domain.SeriesID,
+		Scope:      domain.Scope,
+		ZoneID:     domain.ZoneID,
+		LateCancel: domain.LateCancel,
+		NoShow:     domain.NoShow,
+		Version:    domain.Version,
+	}
+	if !domain.SeatedAt.IsZero() {
+		seatedAt := domain.SeatedAt
+		reservation.SeatedAt = &seatedAt
 	}
+	if !domain.LeftAt.IsZero() {
+		leftAt := domain.LeftAt
+		reservation.LeftAt = &leftAt
+	}
+	if !domain.CanceledAt.IsZero() {
+		canceledAt := domain.CanceledAt
+		reservation.CanceledAt = &canceledAt
+	}
+	if !domain.ApprovalExpiresAt.IsZero() {
+		approvalExpiresAt := domain.ApprovalExpiresAt
+		reservation.ApprovalExpiresAt = &approvalExpiresAt
+	}
+	return reservation
 }
 
 // FromReservationTableDomain преобразует структуру ReservationTable в ReservationTableDTO.
@@ -130,3 +171,310 @@ func fromReservationTableDomain(domain *domain.ReservationTable) *dto.Reservatio
 		TableID:       domain.TableID,
 	}
 }
+
+// ToBookingPolicyDomain преобразует структуру BookingPolicyDTO в BookingPolicy.
+func toBookingPolicyDomain(dto *dto.BookingPolicyDTO) *domain.BookingPolicy {
+	return &domain.BookingPolicy{
+		RestaurantID:        dto.RestaurantID,
+		MinDuration:         time.Duration(dto.MinDurationMinutes) * time.Minute,
+		MaxDuration:         time.Duration(dto.MaxDurationMinutes) * time.Minute,
+		SlotGranularity:     time.Duration(dto.SlotGranularityMinutes) * time.Minute,
+		LeadTime:            time.Duration(dto.LeadTimeMinutes) * time.Minute,
+		BookingHorizon:      time.Duration(dto.BookingHorizonMinutes) * time.Minute,
+		MaxTablesPerBooking: dto.MaxTablesPerBooking,
+		MaxPartySize:        dto.MaxPartySize,
+		TurnoverBuffer:      time.Duration(dto.TurnoverBufferMinutes) * time.Minute,
+		EventMinDuration:    time.Duration(dto.EventMinDurationMinutes) * time.Minute,
+		EventMaxDuration:    time.Duration(dto.EventMaxDurationMinutes) * time.Minute,
+		DepositPartySize:    dto.DepositPartySize,
+		DepositPerGuest:     dto.DepositPerGuest,
+		PeakStart:           time.Duration(dto.PeakStartMinute) * time.Minute,
+		PeakEnd:             time.Duration(dto.PeakEndMinute) * time.Minute,
+		PaymentHold:         time.Duration(dto.PaymentHoldMinutes) * time.Minute,
+		RefundWindow:        time.Duration(dto.RefundWindowMinutes) * time.Minute,
+		CancellationCutoff:  time.Duration(dto.CancellationCutoffMinutes) * time.Minute,
+		MaxActiveBookings:   dto.MaxActiveBookings,
+		ApprovalHold:        time.Duration(dto.ApprovalHoldMinutes) * time.Minute,
+	}
+}
+
+// FromBookingPolicyDomain преобразует структуру BookingPolicy в BookingPolicyDTO.
+func fromBookingPolicyDomain(domain *domain.BookingPolicy) *dto.BookingPolicyDTO {
+	return &dto.BookingPolicyDTO{
+		RestaurantID:              domain.RestaurantID,
+		MinDurationMinutes:        int(domain.MinDuration.Minutes()),
+		MaxDurationMinutes:        int(domain.MaxDuration.Minutes()),
+		SlotGranularityMinutes:    int(domain.SlotGranularity.Minutes()),
+		LeadTimeMinutes:           int(domain.LeadTime.Minutes()),
+		BookingHorizonMinutes:     int(domain.BookingHorizon.Minutes()),
+		MaxTablesPerBooking:       domain.MaxTablesPerBooking,
+		MaxPartySize:              domain.MaxPartySize,
+		TurnoverBufferMinutes:     int(domain.TurnoverBuffer.Minutes()),
+		EventMinDurationMinutes:   int(domain.EventMinDuration.Minutes()),
+		EventMaxDurationMinutes:   int(domain.EventMaxDuration.Minutes()),
+		DepositPartySize:          domain.DepositPartySize,
+		DepositPerGuest:           domain.DepositPerGuest,
+		PeakStartMinute:           int(domain.PeakStart.Minutes()),
+		PeakEndMinute:             int(domain.PeakEnd.Minutes()),
+		PaymentHoldMinutes:        int(domain.PaymentHold.Minutes()),
+		RefundWindowMinutes:       int(domain.RefundWindow.Minutes()),
+		CancellationCutoffMinutes: int(domain.CancellationCutoff.Minutes()),
+		MaxActiveBookings:         domain.MaxActiveBookings,
+		ApprovalHoldMinutes:       int(domain.ApprovalHold.Minutes()),
+	}
+}
+
+// ToOpeningHoursDomain преобразует структуру OpeningHoursDTO в OpeningHours.
+func toOpeningHoursDomain(dto *dto.OpeningHoursDTO, restaurantID string) *domain.OpeningHours {
+	return &domain.OpeningHours{
+		RestaurantID: restaurantID,
+		Weekday:      time.Weekday(dto.Weekday),
+		Open:         time.Duration(dto.OpenMinute) * time.Minute,
+		Close:        time.Duration(dto.CloseMinute) * time.Minute,
+	}
+}
+
+// FromOpeningHoursDomain преобразует структуру OpeningHours в OpeningHoursDTO.
+func fromOpeningHoursDomain(domain *domain.OpeningHours) *dto.OpeningHoursDTO {
+	return &dto.OpeningHoursDTO{
+		Weekday:     int(domain.Weekday),
+		OpenMinute:  int(domain.Open.Minutes()),
+		CloseMinute: int(domain.Close.Minutes()),
+	}
+}
+
+// ToSpecialDayDomain преобразует структуру SpecialDayDTO в SpecialDay.
+func toSpecialDayDomain(dto *dto.SpecialDayDTO) *domain.SpecialDay {
+	return &domain.SpecialDay{
+		RestaurantID: dto.RestaurantID,
+		Date:         dto.Date,
+		Closed:       dto.Closed,
+		Open:         time.Duration(dto.OpenMinute) * time.Minute,
+		Close:        time.Duration(dto.CloseMinute) * time.Minute,
+		Note:         dto.Note,
+	}
+}
+
+// FromSpecialDayDomain преобразует структуру SpecialDay в SpecialDayDTO.
+func fromSpecialDayDomain(domain *domain.SpecialDay) *dto.SpecialDayDTO {
+	return &dto.SpecialDayDTO{
+		RestaurantID: domain.RestaurantID,
+		Date:         domain.Date,
+		Closed:       domain.Closed,
+		OpenMinute:   int(domain.Open.Minutes()),
+		CloseMinute:  int(domain.Close.Minutes()),
+		Note:         domain.Note,
+	}
+}
+
+// ToClosureDomain преобразует структуру ClosureDTO в Closure.
+func toClosureDomain(dto *dto.ClosureDTO) *domain.Closure {
+	return &domain.Closure{
+		ID:           dto.ID,
+		RestaurantID: dto.RestaurantID,
+		StartTime:    dto.StartTime,
+		EndTime:      dto.EndTime,
+		Reason:       dto.Reason,
+	}
+}
+
+// FromClosureDomain преобразует структуру Closure в ClosureDTO.
+func fromClosureDomain(domain *domain.Closure) *dto.ClosureDTO {
+	return &dto.ClosureDTO{
+		ID:           domain.ID,
+		RestaurantID: domain.RestaurantID,
+		StartTime:    domain.StartTime,
+		EndTime:      domain.EndTime,
+		Reason:       domain.Reason,
+	}
+}
+
+// ToTableBlockDomain преобразует структуру TableBlockDTO в TableBlock.
+func toTableBlockDomain(dto *dto.TableBlockDTO) *domain.TableBlock {
+	return &domain.TableBlock{
+		ID:           dto.ID,
+		TableID:      dto.TableID,
+		RestaurantID: dto.RestaurantID,
+		StartTime:    dto.StartTime,
+		EndTime:      dto.EndTime,
+		Reason:       dto.Reason,
+		CreatedBy:    dto.CreatedBy,
+		CreatedAt:    dto.CreatedAt,
+	}
+}
+
+// FromTableBlockDomain преобразует структуру TableBlock в TableBlockDTO.
+func fromTableBlockDomain(domain *domain.TableBlock) *dto.TableBlockDTO {
+	return &dto.TableBlockDTO{
+		ID:           domain.ID,
+		TableID:      domain.TableID,
+		RestaurantID: domain.RestaurantID,
+		StartTime:    domain.StartTime,
+		EndTime:      domain.EndTime,
+		Reason:       domain.Reason,
+		CreatedBy:    domain.CreatedBy,
+		CreatedAt:    domain.CreatedAt,
+	}
+}
+
+// ToWaitlistEntryDomain преобразует структуру WaitlistEntryDTO в WaitlistEntry.
+func toWaitlistEntryDomain(dto *dto.WaitlistEntryDTO) *domain.WaitlistEntry {
+	entry := &domain.WaitlistEntry{
+		ID:           dto.ID,
+		UserID:       dto.UserID,
+		RestaurantID: dto.RestaurantID,
+		StartTime:    dto.StartTime,
+		EndTime:      dto.EndTime,
+		Capacity:     dto.Capacity,
+		Contacts: domain.Contacts{
+			Name:  dto.Contacts.Name,
+			Phone: dto.Contacts.Phone,
+		},
+		Status:        dto.Status,
+		OfferedTables: dto.OfferedTables,
+		ReservationID: dto.ReservationID,
+		CreatedAt:     dto.CreatedAt,
+	}
+	if dto.OfferExpiresAt != nil {
+		entry.OfferExpiresAt = *dto.OfferExpiresAt
+	}
+	return entry
+}
+
+// FromWaitlistEntryDomain преобразует структуру WaitlistEntry в WaitlistEntryDTO.
+func fromWaitlistEntryDomain(domain *domain.WaitlistEntry) *dto.WaitlistEntryDTO {
+	entry := &dto.WaitlistEntryDTO{
+		ID:           domain.ID,
+		UserID:       domain.UserID,
+		RestaurantID: domain.RestaurantID,
+		StartTime:    domain.StartTime,
+		EndTime:      domain.EndTime,
+		Capacity:     domain.Capacity,
+		Contacts: dto.ContactsDTO{
+			Name:  domain.Contacts.Name,
+			Phone: domain.Contacts.Phone,
+		},
+		Status:        domain.Status,
+		OfferedTables: domain.OfferedTables,
+		ReservationID: domain.ReservationID,
+		CreatedAt:     domain.CreatedAt,
+	}
+	if !domain.OfferExpiresAt.IsZero() {
+		expiresAt := domain.OfferExpiresAt
+		entry.OfferExpiresAt = &expiresAt
+	}
+	return entry
+}
+
+// ToRecurrenceRuleDomain преобразует структуру RecurrenceDTO в RecurrenceRule.
+func toRecurrenceRuleDomain(dto *dto.RecurrenceDTO) domain.RecurrenceRule {
+	rule := domain.RecurrenceRule{
+		Frequency: dto.Frequency,
+		Count:     dto.Count,
+	}
+	if dto.Until != nil {
+		rule.Until = *dto.Until
+	}
+	return rule
+}
+
+// FromReservationSeriesDomain преобразует структуру ReservationSeries в ReservationSeriesDTO без броней.
+func fromReservationSeriesDomain(domain *domain.ReservationSeries) *dto.ReservationSeriesDTO {
+	series := &dto.ReservationSeriesDTO{
+		ID:           domain.ID,
+		UserID:       domain.UserID,
+		RestaurantID: domain.RestaurantID,
+		Recurrence: dto.RecurrenceDTO{
+			Frequency: domain.Rule.Frequency,
+			Count:     domain.Rule.Count,
+		},
+		Reservations: make([]dto.ReservationDTO, 0),
+	}
+	if !domain.Rule.Until.IsZero() {
+		until := domain.Rule.Until
+		series.Recurrence.Until = &until
+	}
+	return series
+}
+
+// ToZoneDomain преобразует структуру ZoneDTO в Zone.
+func toZoneDomain(dto *dto.ZoneDTO) *domain.Zone {
+	return &domain.Zone{
+		ID:               dto.ID,
+		RestaurantID:     dto.RestaurantID,
+		Name:             dto.Name,
+		TableIDs:         dto.TableIDs,
+		MinDuration:      time.Duration(dto.MinDurationMinutes) * time.Minute,
+		MaxDuration:      time.Duration(dto.MaxDurationMinutes) * time.Minute,
+		RequiresApproval: dto.RequiresApproval,
+	}
+}
+
+// FromZoneDomain преобразует структуру Zone в ZoneDTO.
+func fromZoneDomain(domain *domain.Zone) *dto.ZoneDTO {
+	return &dto.ZoneDTO{
+		ID:                 domain.ID,
+		RestaurantID:       domain.RestaurantID,
+		Name:               domain.Name,
+		TableIDs:           domain.TableIDs,
+		MinDurationMinutes: int(domain.MinDuration.Minutes()),
+		MaxDurationMinutes: int(domain.MaxDuration.Minutes()),
+		RequiresApproval:   domain.RequiresApproval,
+	}
+}
+
+// FromPaymentDomain преобразует структуру Payment в PaymentDTO.
+func fromPaymentDomain(domain *domain.Payment) *dto.PaymentDTO {
+	return &dto.PaymentDTO{
+		ID:              domain.ID,
+		Amount:          domain.Amount,
+		Currency:        domain.Currency,
+		Status:          domain.Status,
+		ConfirmationURL: domain.ConfirmationURL,
+		ExpiresAt:       domain.ExpiresAt,
+	}
+}
+
+// FromGuestReliabilityDomain преобразует структуру GuestReliability в GuestReliabilityDTO.
+func fromGuestReliabilityDomain(domain *domain.GuestReliability) *dto.GuestReliabilityDTO {
+	return &dto.GuestReliabilityDTO{
+		UserID:      domain.UserID,
+		Visits:      domain.Visits,
+		NoShows:     domain.NoShows,
+		LateCancels: domain.LateCancels,
+		Score:       domain.Score(),
+	}
+}
+
+// FromAuditEntryDomain преобразует структуру AuditEntry в AuditEntryDTO.
+func fromAuditEntryDomain(domain *domain.AuditEntry) *dto.AuditEntryDTO {
+	changes := make(map[string]dto.AuditChangeDTO, len(domain.Changes))
+	for field, c := range domain.Changes {
+		changes[field] = dto.AuditChangeDTO{From: c.From, To: c.To}
+	}
+	return &dto.AuditEntryDTO{
+		ID:           domain.ID,
+		RestaurantID: domain.RestaurantID,
+		EntityType:   domain.EntityType,
+		EntityID:     domain.EntityID,
+		Action:       domain.Action,
+		ActorID:      domain.ActorID,
+		RequestID:    domain.RequestID,
+		Changes:      changes,
+		CreatedAt:    domain.CreatedAt,
+	}
+}
+
+// ToAuditFilterDomain преобразует структуру AuditFilterDTO в AuditFilter.
+func toAuditFilterDomain(dto *dto.AuditFilterDTO) domain.AuditFilter {
+	return domain.AuditFilter{
+		RestaurantID: dto.RestaurantID,
+		EntityType:   dto.EntityType,
+		EntityID:     dto.EntityID,
+		ActorID:      dto.ActorID,
+		From:         dto.From,
+		To:           dto.To,
+		Limit:        dto.Limit,
+		Offset:       dto.Offset,
+	}
+}
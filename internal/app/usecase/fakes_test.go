@@ -0,0 +1,595 @@
+package usecase
+
+import (
+	"booking_system/internal/app/ports"
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/adapters/payments"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testRestaurant = "restaurant-1"
+	testGuest      = "guest-1"
+	testManager    = "manager-1"
+)
+
+// memStorage — хранилище в памяти для тестов сценариев. Методы, которые тестам
+// не нужны, остаются за встроенным интерфейсом и паникуют при вызове.
+type memStorage struct {
+	ports.IStorage
+
+	mu                sync.Mutex
+	restaurants       map[string]domain.Restaurant
+	tables            map[string]domain.Table
+	reservations      map[string]domain.Reservation
+	reservationTables map[string][]string // ID брони — ID ее столиков
+	policies          map[string]domain.BookingPolicy
+	staff             map[string]string // restaurantID/userID — роль
+	payments          map[string]domain.Payment
+	idempotency       map[string]domain.IdempotencyRecord // userID/key — запись
+	waitlist          map[string]domain.WaitlistEntry
+	series            map[string]domain.ReservationSeries
+	audit             []domain.AuditEntry
+
+	completeFailures int // Сколько первых вызовов CompleteIdempotencyKey завершатся ошибкой
+	createLimit      int // Сколько броней CreateReservation создаст до ошибки базы, 0 — без ограничения
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		restaurants:       make(map[string]domain.Restaurant),
+		tables:            make(map[string]domain.Table),
+		reservations:      make(map[string]domain.Reservation),
+		reservationTables: make(map[string][]string),
+		policies:          make(map[string]domain.BookingPolicy),
+		staff:             make(map[string]string),
+		payments:          make(map[string]domain.Payment),
+		idempotency:       make(map[string]domain.IdempotencyRecord),
+		waitlist:          make(map[string]domain.WaitlistEntry),
+		series:            make(map[string]domain.ReservationSeries),
+	}
+}
+
+// newTestService собирает сценарии поверх хранилища в памяти и поддельного платежного провайдера.
+// В хранилище уже есть ресторан testRestaurant в UTC и его менеджер testManager.
+func newTestService(t *testing.T) (UserService, *memStorage, *payments.Fake) {
+	t.Helper()
+	storage := newMemStorage()
+	storage.restaurants[testRestaurant] = domain.Restaurant{ID: testRestaurant, Name: "Test", Timezone: "UTC"}
+	storage.staff[testRestaurant+"/"+testManager] = domain.StaffRoleManager
+	provider := payments.NewFake("RUB", "http://localhost/return")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return New(storage, logger, "", nil, nopMetrics{}, nopEvents{}, provider, 15*time.Minute), storage, provider
+}
+
+// addTable добавляет столик ресторану testRestaurant.
+func (s *memStorage) addTable(id string, capacity int) domain.Table {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	table := domain.Table{ID: id, RestaurantID: testRestaurant, TableNumber: len(s.tables) + 1, Capacity: capacity}
+	s.tables[id] = table
+	return table
+}
+
+// addReservation сохраняет бронь на столиках tableIDs, как будто она уже создана.
+func (s *memStorage) addReservation(reservation domain.Reservation, tableIDs ...string) domain.Reservation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reservation.RestaurantID == "" {
+		reservation.RestaurantID = testRestaurant
+	}
+	if reservation.Version == 0 {
+		reservation.Version = 1
+	}
+	s.reservations[reservation.ID] = reservation
+	s.reservationTables[reservation.ID] = tableIDs
+	return reservation
+}
+
+// reservation возвращает сохраненное состояние брони.
+func (s *memStorage) reservation(t *testing.T, id string) domain.Reservation {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reservation, ok := s.reservations[id]
+	if !ok {
+		t.Fatalf("reservation %s not stored", id)
+	}
+	return reservation
+}
+
+// addOffer сохраняет запись листа ожидания гостя testGuest с действующим предложением столиков tableIDs.
+func (s *memStorage) addOffer(id string, start time.Time, tableIDs ...string) domain.WaitlistEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := domain.WaitlistEntry{
+		ID:             id,
+		UserID:         testGuest,
+		RestaurantID:   testRestaurant,
+		StartTime:      start,
+		EndTime:        start.Add(time.Hour),
+		Capacity:       2,
+		Status:         domain.WaitlistOffered,
+		OfferedTables:  tableIDs,
+		OfferExpiresAt: time.Now().Add(10 * time.Minute),
+	}
+	s.waitlist[id] = entry
+	return entry
+}
+
+// waitlistEntry возвращает сохраненное состояние записи листа ожидания.
+func (s *memStorage) waitlistEntry(id string) domain.WaitlistEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waitlist[id]
+}
+
+// auditEntries возвращает записи журнала аудита о сущности entityID.
+func (s *memStorage) auditEntries(entityID string) []domain.AuditEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []domain.AuditEntry
+	for _, entry := range s.audit {
+		if entry.EntityID == entityID {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func (s *memStorage) GetRestaurant(_ context.Context, restaurantID string) (*domain.Restaurant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	restaurant, ok := s.restaurants[restaurantID]
+	if !ok {
+		return nil, domain.ErrRestaurantNotFound
+	}
+	return &restaurant, nil
+}
+
+func (s *memStorage) GetBookingPolicy(_ context.Context, restaurantID string) (*domain.BookingPolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	policy, ok := s.policies[restaurantID]
+	if !ok {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
+func (s *memStorage) GetSchedule(context.Context, string, time.Time, time.Time) (*domain.Schedule, error) {
+	return &domain.Schedule{}, nil
+}
+
+func (s *memStorage) GetStaffRole(_ context.Context, restaurantID string, userID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.staff[restaurantID+"/"+userID], nil
+}
+
+func (s *memStorage) HasGuestReservation(_ context.Context, userID string, restaurantID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.reservations {
+		if r.UserID == userID && r.RestaurantID == restaurantID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *memStorage) GetGuestReliability(_ context.Context, userID string) (*domain.GuestReliability, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reliability := &domain.GuestReliability{UserID: userID}
+	for _, r := range s.reservations {
+		if r.UserID != userID {
+			continue
+		}
+		if !r.SeatedAt.IsZero() {
+			reliability.Visits++
+		}
+		if r.NoShow {
+			reliability.NoShows++
+		}
+		if r.LateCancel {
+			reliability.LateCancels++
+		}
+	}
+	return reliability, nil
+}
+
+// checkActiveReservations повторяет проверку лимита действующих броней из транзакции вставки.
+func (s *memStorage) checkActiveReservations(reservation *domain.Reservation, maxActive int) error {
+	if maxActive <= 0 || reservation.UserID == "" {
+		return nil
+	}
+	var count int
+	for _, r := range s.reservations {
+		if r.UserID == reservation.UserID && r.RestaurantID == reservation.RestaurantID && r.Status != domain.ReservationCanceled && r.EndTime.After(time.Now()) {
+			count++
+		}
+	}
+	if count >= maxActive {
+		return domain.ErrTooManyReservations
+	}
+	return nil
+}
+
+func (s *memStorage) GetTable(_ context.Context, tableID string) (*domain.Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	table, ok := s.tables[tableID]
+	if !ok {
+		return nil, domain.ErrTableNotFound.Withf("table %s not found", tableID)
+	}
+	return &table, nil
+}
+
+func (s *memStorage) GetRestaurantTables(_ context.Context, restaurantID string) ([]domain.Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var tables []domain.Table
+	for _, t := range s.tables {
+		if t.RestaurantID == restaurantID {
+			tables = append(tables, t)
+		}
+	}
+	slices.SortFunc(tables, func(a, b domain.Table) int { return a.TableNumber - b.TableNumber })
+	return tables, nil
+}
+
+func (s *memStorage) GetTablesByReservationID(_ context.Context, reservationID string) ([]domain.Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tables := make([]domain.Table, 0, len(s.reservationTables[reservationID]))
+	for _, id := range s.reservationTables[reservationID] {
+		tables = append(tables, s.tables[id])
+	}
+	return tables, nil
+}
+
+func (s *memStorage) IsTableAvailable(_ context.Context, tableID string, startTime, endTime time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tableAvailable(tableID, startTime, endTime, "", ""), nil
+}
+
+// tableAvailable повторяет проверку хранилища: столик занимают неотмененные брони
+// и действующие предложения листа ожидания, кроме excludeReservationID и excludeEntryID.
+func (s *memStorage) tableAvailable(tableID string, startTime, endTime time.Time, excludeReservationID string, excludeEntryID string) bool {
+	for id, r := range s.reservations {
+		if id == excludeReservationID || r.Status == domain.ReservationCanceled {
+			continue
+		}
+		if slices.Contains(s.reservationTables[id], tableID) && startTime.Before(r.EndTime) && endTime.After(r.StartTime) {
+			return false
+		}
+	}
+	for id, e := range s.waitlist {
+		if id == excludeEntryID || !e.OfferActive(time.Now()) {
+			continue
+		}
+		if slices.Contains(e.OfferedTables, tableID) && startTime.Before(e.EndTime) && endTime.After(e.StartTime) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *memStorage) CreateReservation(_ context.Context, reservation *domain.Reservation, tableIDs map[string]string, maxActive int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.createLimit > 0 && len(s.reservations) >= s.createLimit {
+		return "", errors.New("connection reset")
+	}
+	if err := s.checkActiveReservations(reservation, maxActive); err != nil {
+		return "", err
+	}
+	s.createReservation(reservation, tableIDs)
+	return reservation.ID, nil
+}
+
+func (s *memStorage) createReservation(reservation *domain.Reservation, tableIDs map[string]string) {
+	if reservation.Version == 0 {
+		reservation.Version = 1
+	}
+	s.reservations[reservation.ID] = *reservation
+	ids := make([]string, 0, len(tableIDs))
+	for _, id := range tableIDs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	s.reservationTables[reservation.ID] = ids
+}
+
+func (s *memStorage) GetReservationForId(_ context.Context, id string) (*domain.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reservation, ok := s.reservations[id]
+	if !ok {
+		return nil, nil
+	}
+	return &reservation, nil
+}
+
+func (s *memStorage) UpdateReservation(_ context.Context, reservation *domain.Reservation) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.reservations[reservation.ID]
+	if !ok || stored.Version != reservation.Version {
+		return false, nil
+	}
+	reservation.Version++
+	s.reservations[reservation.ID] = *reservation
+	return true, nil
+}
+
+func (s *memStorage) UpdateReservationStatus(_ context.Context, id string, from, to string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.reservations[id]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	stored.Status = to
+	stored.Version++
+	s.reservations[id] = stored
+	return true, nil
+}
+
+func (s *memStorage) RescheduleReservation(_ context.Context, reservation *domain.Reservation, tableIDs map[string]string, buffer time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tableID := range tableIDs {
+		if !s.tableAvailable(tableID, reservation.StartTime.Add(-buffer), reservation.EndTime.Add(buffer), reservation.ID, "") {
+			return domain.ErrTableNotAvailable.Withf("table %s not available", tableID)
+		}
+	}
+	stored, ok := s.reservations[reservation.ID]
+	if !ok || stored.Status == domain.ReservationCanceled || stored.Version != reservation.Version {
+		return domain.ErrReservationModified
+	}
+	reservation.Version++
+	s.createReservation(reservation, tableIDs)
+	return nil
+}
+
+func (s *memStorage) CreateReservationSeries(_ context.Context, series *domain.ReservationSeries) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.series[series.ID] = *series
+	return nil
+}
+
+func (s *memStorage) DeleteReservationSeries(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.series, id)
+	for rid, r := range s.reservations {
+		if r.SeriesID == id {
+			r.SeriesID = ""
+			s.reservations[rid] = r
+		}
+	}
+	return nil
+}
+
+func (s *memStorage) CancelSeriesReservations(_ context.Context, seriesID string, from time.Time, cutoff time.Duration) ([]*domain.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var canceled []*domain.Reservation
+	for id, r := range s.reservations {
+		if r.SeriesID != seriesID || r.Status == domain.ReservationCanceled || r.StartTime.Before(from) || !r.SeatedAt.IsZero() {
+			continue
+		}
+		r.Status = domain.ReservationCanceled
+		r.CanceledAt = from
+		r.LateCancel = r.StartTime.Before(from.Add(cutoff))
+		r.Version++
+		s.reservations[id] = r
+		canceled = append(canceled, &r)
+	}
+	slices.SortFunc(canceled, func(a, b *domain.Reservation) int { return a.StartTime.Compare(b.StartTime) })
+	return canceled, nil
+}
+
+func (s *memStorage) GetExpiredApprovals(_ context.Context, now time.Time) ([]*domain.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var expired []*domain.Reservation
+	for _, r := range s.reservations {
+		if r.Status == domain.ReservationWait && !r.ApprovalExpiresAt.IsZero() && !r.ApprovalExpiresAt.After(now) {
+			expired = append(expired, &r)
+		}
+	}
+	return expired, nil
+}
+
+func (s *memStorage) CreatePayment(_ context.Context, payment *domain.Payment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.payments[payment.ID] = *payment
+	return nil
+}
+
+func (s *memStorage) GetPaymentByExternalID(_ context.Context, provider string, externalID string) (*domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.payments {
+		if p.Provider == provider && p.ExternalID == externalID {
+			return &p, nil
+		}
+	}
+	return nil, domain.ErrPaymentNotFound
+}
+
+func (s *memStorage) GetReservationPayment(_ context.Context, reservationID string) (*domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest *domain.Payment
+	for _, p := range s.payments {
+		if p.ReservationID == reservationID && (latest == nil || p.CreatedAt.After(latest.CreatedAt)) {
+			latest = &p
+		}
+	}
+	return latest, nil
+}
+
+func (s *memStorage) UpdatePaymentStatus(_ context.Context, id string, from, to string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payment, ok := s.payments[id]
+	if !ok || payment.Status != from {
+		return false, nil
+	}
+	payment.Status = to
+	s.payments[id] = payment
+	return true, nil
+}
+
+func (s *memStorage) GetExpiredPayments(_ context.Context, now time.Time) ([]domain.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var expired []domain.Payment
+	for _, p := range s.payments {
+		if p.Status == domain.PaymentPending && !p.ExpiresAt.After(now) {
+			expired = append(expired, p)
+		}
+	}
+	return expired, nil
+}
+
+func (s *memStorage) GetWaitlistEntry(_ context.Context, id string) (*domain.WaitlistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.waitlist[id]
+	if !ok {
+		return nil, domain.ErrWaitlistNotFound
+	}
+	return &entry, nil
+}
+
+func (s *memStorage) GetWaitingEntries(_ context.Context, restaurantID string, from, to time.Time) ([]domain.WaitlistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []domain.WaitlistEntry
+	for _, e := range s.waitlist {
+		if e.RestaurantID == restaurantID && e.Status == domain.WaitlistWaiting && e.StartTime.Before(to) && e.EndTime.After(from) {
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b domain.WaitlistEntry) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return entries, nil
+}
+
+func (s *memStorage) UpdateWaitlistEntry(_ context.Context, entry *domain.WaitlistEntry, fromStatus string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.waitlist[entry.ID]
+	if !ok || stored.Status != fromStatus {
+		return false, nil
+	}
+	s.waitlist[entry.ID] = *entry
+	return true, nil
+}
+
+func (s *memStorage) ClaimWaitlistOffer(_ context.Context, entry *domain.WaitlistEntry, reservation *domain.Reservation, tableIDs map[string]string, buffer time.Duration, maxActive int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.waitlist[entry.ID]
+	if !ok || !stored.OfferActive(time.Now()) {
+		return domain.ErrNoActiveOffer
+	}
+	for _, tableID := range tableIDs {
+		if !s.tableAvailable(tableID, reservation.StartTime.Add(-buffer), reservation.EndTime.Add(buffer), "", entry.ID) {
+			return domain.ErrTableNotAvailable.Withf("table %s not available", tableID)
+		}
+	}
+	if err := s.checkActiveReservations(reservation, maxActive); err != nil {
+		return err
+	}
+	s.createReservation(reservation, tableIDs)
+	stored.Status = domain.WaitlistClaimed
+	stored.ReservationID = reservation.ID
+	s.waitlist[entry.ID] = stored
+	*entry = stored
+	return nil
+}
+
+func (s *memStorage) AcquireIdempotencyKey(_ context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := record.UserID + "/" + record.Key
+	existing, ok := s.idempotency[id]
+	now := record.CreatedAt
+	abandoned := existing.Response == nil && !existing.CreatedAt.After(now.Add(-domain.IdempotencyLockTimeout))
+	if ok && existing.ExpiresAt.After(now) && !abandoned {
+		return &existing, nil
+	}
+	s.idempotency[id] = *record
+	return nil, nil
+}
+
+func (s *memStorage) CompleteIdempotencyKey(ctx context.Context, userID string, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.completeFailures > 0 {
+		s.completeFailures--
+		return errors.New("connection reset")
+	}
+	record := s.idempotency[userID+"/"+key]
+	record.Response = response
+	s.idempotency[userID+"/"+key] = record
+	return nil
+}
+
+func (s *memStorage) ReleaseIdempotencyKey(_ context.Context, userID string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.idempotency[userID+"/"+key]; ok && record.Response == nil {
+		delete(s.idempotency, userID+"/"+key)
+	}
+	return nil
+}
+
+func (s *memStorage) AppendAuditEntry(_ context.Context, entry *domain.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.audit = append(s.audit, *entry)
+	return nil
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ReservationCreated(string)        {}
+func (nopMetrics) ReservationCanceled(string)       {}
+func (nopMetrics) ReservationFailed(string, string) {}
+
+// memMetrics запоминает неудачные брони в виде "restaurantID/reason".
+type memMetrics struct {
+	nopMetrics
+
+	mu     sync.Mutex
+	failed []string
+}
+
+func (m *memMetrics) ReservationFailed(restaurantID string, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed = append(m.failed, restaurantID+"/"+reason)
+}
+
+type nopEvents struct{}
+
+func (nopEvents) Publish(context.Context, string, interface{}) error { return nil }
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+const (
+	idempotencyCompleteAttempts = 5                      // Сколько раз пытаться сохранить результат запроса
+	idempotencyRetryDelay       = 100 * time.Millisecond // Пауза перед повтором, растет с каждой попыткой
+)
+
+// CreateReservationIdempotent создает бронь не более одного раза для ключа идемпотентности
+// гостя. Повтор с тем же содержимым возвращает сохраненный результат и replayed = true,
+// повтор с другим содержимым отклоняется. Неудачный запрос освобождает ключ для повтора.
+func (u UserService) CreateReservationIdempotent(ctx context.Context, key string, reservation dto.ReservationDTO) (_ dto.ReservationDTO, replayed bool, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateReservationIdempotent")
+	defer func() { endSpan(span, err) }()
+
+	fingerprint, err := reservationFingerprint(reservation)
+	if err != nil {
+		return dto.ReservationDTO{}, false, err
+	}
+	now := time.Now()
+	record := &domain.IdempotencyRecord{
+		UserID:      reservation.UserID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(domain.IdempotencyTTL),
+	}
+	existing, err := u.storage.AcquireIdempotencyKey(ctx, record)
+	if err != nil {
+		return dto.ReservationDTO{}, false, err
+	}
+	if existing != nil {
+		if err = existing.Replay(fingerprint); err != nil {
+			return dto.ReservationDTO{}, false, err
+		}
+		var result dto.ReservationDTO
+		if err = json.Unmarshal(existing.Response, &result); err != nil {
+			return dto.ReservationDTO{}, false, err
+		}
+		u.logger.Info("Idempotent request replayed", "reservation_id", result.ID, "user_id", reservation.UserID)
+		return result, true, nil
+	}
+
+	created, err := u.CreateReservation(ctx, reservation)
+	if err != nil {
+		if releaseErr := u.storage.ReleaseIdempotencyKey(ctx, reservation.UserID, key); releaseErr != nil {
+			u.logger.Error("Failed to release idempotency key", "user_id", reservation.UserID, "error", releaseErr)
+		}
+		return dto.ReservationDTO{}, false, err
+	}
+	// Бронь уже создана, поэтому ошибка сохранения результата только логируется.
+	response, err := json.Marshal(created)
+	if err == nil {
+		err = u.completeIdempotencyKey(ctx, reservation.UserID, key, response)
+	}
+	if err != nil {
+		u.logger.Error("Failed to store idempotent response", "reservation_id", created.ID, "error", err)
+	}
+	return created, false, nil
+}
+
+// completeIdempotencyKey сохраняет результат запроса, повторяя запись при ошибке. Ключ без
+// результата через IdempotencyLockTimeout считается брошенным, и повтор запроса создал бы
+// вторую бронь. Запись не зависит от отмены ctx: бронь уже создана, и обрыв соединения
+// или таймаут запроса не должны оставить ключ незавершенным.
+func (u UserService) completeIdempotencyKey(ctx context.Context, userID string, key string, response []byte) error {
+	ctx = context.WithoutCancel(ctx)
+	var err error
+	for attempt := 1; attempt <= idempotencyCompleteAttempts; attempt++ {
+		if err = u.storage.CompleteIdempotencyKey(ctx, userID, key, response); err == nil {
+			return nil
+		}
+		if attempt < idempotencyCompleteAttempts {
+			u.logger.Warn("Retrying idempotent response store", "user_id", userID, "attempt", attempt, "error", err)
+			time.Sleep(time.Duration(attempt) * idempotencyRetryDelay)
+		}
+	}
+	return err
+}
+
+// ExpireIdempotencyKeys удаляет ключи идемпотентности с истекшим сроком хранения.
+func (u UserService) ExpireIdempotencyKeys(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ExpireIdempotencyKeys")
+	defer func() { endSpan(span, err) }()
+
+	deleted, err := u.storage.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		u.logger.Info("Expired idempotency keys deleted", "count", deleted)
+	}
+	return nil
+}
+
+// reservationFingerprint хеширует содержимое запроса на бронь. Время приводится к UTC,
+// чтобы один и тот же момент в разных часовых поясах давал один отпечаток.
+func reservationFingerprint(reservation dto.ReservationDTO) (string, error) {
+	tables := make([]string, 0, len(reservation.Table))
+	for _, t := range reservation.Table {
+		tables = append(tables, t.ID)
+	}
+	data, err := json.Marshal(struct {
+		RestaurantID string
+		StartTime    time.Time
+		EndTime      time.Time
+		Tables       []string
+		Capacity     int
+		Contacts     dto.ContactsDTO
+	}{
+		RestaurantID: reservation.RestaurantID,
+		StartTime:    reservation.StartTime.UTC(),
+		EndTime:      reservation.EndTime.UTC(),
+		Tables:       tables,
+		Capacity:     reservation.Capacity,
+		Contacts:     reservation.Contacts,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
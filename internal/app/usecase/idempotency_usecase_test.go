@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func idempotentBooking(start time.Time, capacity int) dto.ReservationDTO {
+	return dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "t1"}},
+		Capacity:     capacity,
+	}
+}
+
+func TestCreateReservationIdempotent(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	tests := []struct {
+		name             string
+		completeFailures int
+		retry            dto.ReservationDTO
+		wantErr          error
+	}{
+		{name: "replay returns stored result", retry: idempotentBooking(start, 2)},
+		{name: "replay after completion was retried", completeFailures: 2, retry: idempotentBooking(start, 2)},
+		{name: "same key with other body is rejected", retry: idempotentBooking(start, 3), wantErr: domain.ErrIdempotencyKeyReused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, _ := newTestService(t)
+			storage.addTable("t1", 4)
+			storage.completeFailures = tt.completeFailures
+
+			first, replayed, err := service.CreateReservationIdempotent(context.Background(), "key-1", idempotentBooking(start, 2))
+			if err != nil || replayed {
+				t.Fatalf("first request: replayed %v, err %v", replayed, err)
+			}
+			if storage.idempotency[testGuest+"/key-1"].Response == nil {
+				t.Fatal("response is not stored")
+			}
+
+			second, replayed, err := service.CreateReservationIdempotent(context.Background(), "key-1", tt.retry)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("retry err = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil || !replayed {
+					t.Fatalf("retry: replayed %v, err %v", replayed, err)
+				}
+				if second.ID != first.ID {
+					t.Errorf("retry returned reservation %s, want %s", second.ID, first.ID)
+				}
+			}
+			if n := len(storage.reservations); n != 1 {
+				t.Errorf("stored %d reservations, want 1", n)
+			}
+		})
+	}
+}
+
+func TestCreateReservationIdempotentInProgress(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	fingerprint, err := reservationFingerprint(idempotentBooking(start, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	storage.idempotency[testGuest+"/key-1"] = domain.IdempotencyRecord{
+		UserID:      testGuest,
+		Key:         "key-1",
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(domain.IdempotencyTTL),
+	}
+
+	_, _, err = service.CreateReservationIdempotent(context.Background(), "key-1", idempotentBooking(start, 2))
+	if !errors.Is(err, domain.ErrIdempotencyInProgress) {
+		t.Fatalf("err = %v, want ErrIdempotencyInProgress", err)
+	}
+	if n := len(storage.reservations); n != 0 {
+		t.Errorf("stored %d reservations, want none", n)
+	}
+}
+
+func TestCreateReservationIdempotentReleasesKeyOnFailure(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	_, _, err := service.CreateReservationIdempotent(context.Background(), "key-1", idempotentBooking(start, 2))
+	if !errors.Is(err, domain.ErrTableNotFound) {
+		t.Fatalf("err = %v, want ErrTableNotFound", err)
+	}
+	if _, ok := storage.idempotency[testGuest+"/key-1"]; ok {
+		t.Fatal("key of failed request is not released")
+	}
+
+	storage.addTable("t1", 4)
+	if _, replayed, err := service.CreateReservationIdempotent(context.Background(), "key-1", idempotentBooking(start, 2)); err != nil || replayed {
+		t.Fatalf("retry after failure: replayed %v, err %v", replayed, err)
+	}
+}
+
+func TestCreateReservationIdempotentCompletesAfterCancel(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	// Контекст запроса отменен: клиент отключился или истек таймаут. В хранилище в памяти
+	// на отмену реагирует только CompleteIdempotencyKey, поэтому бронь успевает создаться.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := service.CreateReservationIdempotent(ctx, "key-1", idempotentBooking(start, 2)); err != nil {
+		t.Fatalf("CreateReservationIdempotent: %v", err)
+	}
+	if storage.idempotency[testGuest+"/key-1"].Response == nil {
+		t.Fatal("response is not stored after request context was canceled")
+	}
+}
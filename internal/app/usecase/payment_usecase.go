@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// HandlePaymentNotification применяет уведомление провайдера: оплаченный депозит
+// подтверждает бронь, отклоненный снимает придержку столиков. Деньги, пришедшие
+// после того, как бронь уже отменена или истек срок оплаты, возвращаются гостю.
+func (u UserService) HandlePaymentNotification(ctx context.Context, body []byte) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.HandlePaymentNotification")
+	defer func() { endSpan(span, err) }()
+
+	notification, err := u.payments.ParseNotification(ctx, body)
+	if err != nil {
+		return err
+	}
+	if notification.Status != domain.PaymentPaid && notification.Status != domain.PaymentFailed {
+		u.logger.Debug("Payment notification skipped", "external_id", notification.ExternalID, "status", notification.Status)
+		return nil
+	}
+	payment, err := u.storage.GetPaymentByExternalID(ctx, u.payments.Name(), notification.ExternalID)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		// Провайдер может прислать уведомление о платеже, который мы не сохранили.
+		u.logger.Warn("Notification for unknown payment", "external_id", notification.ExternalID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if notification.Status == domain.PaymentFailed {
+		ok, err := u.storage.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentFailed)
+		if err != nil || !ok {
+			return err
+		}
+		u.logger.Info("Deposit payment failed", "payment_id", payment.ID, "reservation_id", payment.ReservationID)
+		return u.releaseHold(ctx, payment.ReservationID, domain.AuditCancel)
+	}
+
+	ok, err := u.storage.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentPaid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if payment.Status == domain.PaymentPaid || payment.Status == domain.PaymentRefunded {
+			return nil // Повторное уведомление
+		}
+		u.logger.Warn("Deposit paid after hold was released", "payment_id", payment.ID, "status", payment.Status)
+		return u.refundDeposit(ctx, *payment, payment.Status)
+	}
+	confirmed, err := u.storage.UpdateReservationStatus(ctx, payment.ReservationID, domain.ReservationPendingPayment, domain.ReservationConfirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		u.logger.Warn("Deposit paid for reservation that is no longer held", "payment_id", payment.ID, "reservation_id", payment.ReservationID)
+		return u.refundDeposit(ctx, *payment, domain.PaymentPaid)
+	}
+	u.logger.Info("Deposit paid, reservation confirmed", "payment_id", payment.ID, "reservation_id", payment.ReservationID)
+	reservation, err := u.storage.GetReservationForId(ctx, payment.ReservationID)
+	if err != nil || reservation == nil {
+		return err
+	}
+	u.auditStatusChange(ctx, domain.AuditActorSystem, domain.AuditPay, reservation, domain.ReservationPendingPayment)
+	return nil
+}
+
+// ExpirePayments снимает придержку столиков с броней, депозит за которые не оплачен вовремя.
+func (u UserService) ExpirePayments(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ExpirePayments")
+	defer func() { endSpan(span, err) }()
+
+	payments, err := u.storage.GetExpiredPayments(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		ok, err := u.storage.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentExpired)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		u.logger.Info("Deposit payment expired", "payment_id", payment.ID, "reservation_id", payment.ReservationID)
+		if err = u.releaseHold(ctx, payment.ReservationID, domain.AuditExpire); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requestDeposit регистрирует у провайдера платеж за только что созданную бронь.
+func (u UserService) requestDeposit(ctx context.Context, reservation *domain.Reservation, amount int64, policy domain.BookingPolicy) (*domain.Payment, error) {
+	now := time.Now()
+	payment := &domain.Payment{
+		ID:            uuid.New().String(),
+		ReservationID: reservation.ID,
+		Provider:      u.payments.Name(),
+		Amount:        amount,
+		Currency:      u.payments.Currency(),
+		Status:        domain.PaymentPending,
+		ExpiresAt:     now.Add(policy.PaymentHoldFor()),
+		CreatedAt:     now,
+	}
+	intent, err := u.payments.CreatePayment(ctx, *payment, fmt.Sprintf("Депозит за бронь %s", reservation.ID))
+	if err != nil {
+		return nil, domain.ErrPaymentUnavailable.Wrap(err)
+	}
+	payment.ExternalID = intent.ExternalID
+	payment.ConfirmationURL = intent.ConfirmationURL
+	if err = u.storage.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+	u.logger.Info("Deposit requested", "payment_id", payment.ID, "reservation_id", reservation.ID, "amount", amount)
+	return payment, nil
+}
+
+// collectDeposit запрашивает депозит за только что созданную бронь, придержанную в статусе
+// ReservationPendingPayment. Если провайдер недоступен, бронь отменяется, чтобы не держать
+// столики за платежом, который гость не сможет внести.
+func (u UserService) collectDeposit(ctx context.Context, reservation *domain.Reservation, amount int64, policy domain.BookingPolicy) (*domain.Payment, error) {
+	payment, err := u.requestDeposit(ctx, reservation, amount, policy)
+	if err == nil {
+		return payment, nil
+	}
+	if _, cancelErr := u.storage.UpdateReservationStatus(ctx, reservation.ID, domain.ReservationPendingPayment, domain.ReservationCanceled); cancelErr != nil {
+		u.logger.Error("Failed to release reservation without deposit", "reservation_id", reservation.ID, "error", cancelErr)
+	} else {
+		released := *reservation
+		released.Status = domain.ReservationCanceled
+		u.auditReservation(ctx, domain.AuditActorSystem, domain.AuditCancel, reservation, &released)
+	}
+	return nil, err
+}
+
+// checkDepositCovered проверяет, что измененной брони хватает уже запрошенного или внесенного
+// депозита. У брони один платеж и доплата не поддерживается, поэтому изменение, после которого
+// правила требуют большего депозита, отклоняется с ErrDepositIncrease.
+func (u UserService) checkDepositCovered(ctx context.Context, reservation *domain.Reservation, policy domain.BookingPolicy, loc *time.Location) error {
+	required := policy.Deposit(*reservation, loc)
+	if required == 0 {
+		return nil
+	}
+	payment, err := u.storage.GetReservationPayment(ctx, reservation.ID)
+	if err != nil {
+		return err
+	}
+	var covered int64
+	if payment != nil && (payment.Status == domain.PaymentPending || payment.Status == domain.PaymentPaid) {
+		covered = payment.Amount
+	}
+	if required > covered {
+		return domain.ErrDepositIncrease.Withf("the change requires a deposit of %d, only %d is covered; cancel the reservation and book again", required, covered)
+	}
+	return nil
+}
+
+// releaseHold отменяет бронь, все еще ожидающую оплаты, и предлагает ее столики листу ожидания.
+// action — под каким действием отмена попадет в журнал аудита.
+func (u UserService) releaseHold(ctx context.Context, reservationId string, action string) error {
+	ok, err := u.storage.UpdateReservationStatus(ctx, reservationId, domain.ReservationPendingPayment, domain.ReservationCanceled)
+	if err != nil || !ok {
+		return err
+	}
+	reservation, err := u.storage.GetReservationForId(ctx, reservationId)
+	if err != nil || reservation == nil {
+		return err
+	}
+	u.auditStatusChange(ctx, domain.AuditActorSystem, action, reservation, domain.ReservationPendingPayment)
+	u.metrics.ReservationCanceled(reservation.RestaurantID)
+	u.offerFreedSlot(ctx, reservation.RestaurantID, reservation.StartTime, reservation.EndTime)
+	return nil
+}
+
+// settleDeposit закрывает депозит отмененной брони: неоплаченный платеж отменяется,
+// оплаченный возвращается, если гость отменил бронь не позже RefundWindow до начала.
+// Ошибки только логируются: отмена брони уже состоялась.
+func (u UserService) settleDeposit(ctx context.Context, reservation *domain.Reservation) {
+	payment, err := u.storage.GetReservationPayment(ctx, reservation.ID)
+	if err != nil {
+		u.logger.Error("Failed to load reservation payment", "reservation_id", reservation.ID, "error", err)
+		return
+	}
+	if payment == nil {
+		return
+	}
+	switch payment.Status {
+	case domain.PaymentPending:
+		// Если оплата придет позже, HandlePaymentNotification вернет ее.
+		if _, err = u.storage.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentCanceled); err != nil {
+			u.logger.Error("Failed to cancel pending payment", "payment_id", payment.ID, "error", err)
+		}
+	case domain.PaymentPaid:
+		policy, err := u.bookingPolicy(ctx, reservation.RestaurantID)
+		if err != nil {
+			u.logger.Error("Failed to load booking policy for refund", "reservation_id", reservation.ID, "error", err)
+			return
+		}
+		if !policy.RefundDue(*reservation, time.Now()) {
+			u.logger.Info("Deposit retained after late cancellation", "payment_id", payment.ID, "reservation_id", reservation.ID)
+			return
+		}
+		if err = u.refundDeposit(ctx, *payment, domain.PaymentPaid); err != nil {
+			u.logger.Error("Failed to refund deposit", "payment_id", payment.ID, "error", err)
+		}
+	}
+}
+
+// refundDeposit возвращает депозит гостю и отмечает платеж возвращенным, если его статус все еще from.
+func (u UserService) refundDeposit(ctx context.Context, payment domain.Payment, from string) error {
+	if err := u.payments.Refund(ctx, payment); err != nil {
+		return err
+	}
+	if _, err := u.storage.UpdatePaymentStatus(ctx, payment.ID, from, domain.PaymentRefunded); err != nil {
+		return err
+	}
+	u.logger.Info("Deposit refunded", "payment_id", payment.ID, "reservation_id", payment.ReservationID)
+	return nil
+}
+
+// withPayment добавляет к брони ее депозит, если он был.
+func (u UserService) withPayment(ctx context.Context, reservation *dto.ReservationDTO) error {
+	payment, err := u.storage.GetReservationPayment(ctx, reservation.ID)
+	if err != nil || payment == nil {
+		return err
+	}
+	reservation.Payment = fromPaymentDomain(payment)
+	return nil
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"booking_system/internal/infrastructure/adapters/payments"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// bookWithDeposit создает бронь на четверых, за которую политика ресторана требует депозит.
+func bookWithDeposit(t *testing.T, service UserService, storage *memStorage) (dto.ReservationDTO, domain.Payment) {
+	t.Helper()
+	storage.addTable("t1", 4)
+	storage.policies[testRestaurant] = domain.BookingPolicy{
+		RestaurantID:     testRestaurant,
+		MaxDuration:      2 * time.Hour,
+		DepositPartySize: 4,
+		DepositPerGuest:  50000,
+	}
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	created, err := service.CreateReservation(context.Background(), dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "t1"}},
+		Capacity:     4,
+	})
+	if err != nil {
+		t.Fatalf("CreateReservation: %v", err)
+	}
+	if created.Status != domain.ReservationPendingPayment || created.Payment == nil {
+		t.Fatalf("reservation %s without payment, want pending deposit", created.Status)
+	}
+	payment := storage.payments[created.Payment.ID]
+	if payment.Amount != 200000 || payment.Provider != "fake" {
+		t.Fatalf("payment = %+v, want 200000 via fake provider", payment)
+	}
+	return created, payment
+}
+
+func notify(t *testing.T, service UserService, payment domain.Payment, status string) {
+	t.Helper()
+	body, err := json.Marshal(payments.FakeNotification{PaymentID: payment.ExternalID, Status: status})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.HandlePaymentNotification(context.Background(), body); err != nil {
+		t.Fatalf("HandlePaymentNotification: %v", err)
+	}
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	tests := []struct {
+		name              string
+		expireFirst       bool
+		status            string
+		wantReservation   string
+		wantPayment       string
+		wantRefundedByPSP bool
+	}{
+		{
+			name:            "paid confirms reservation",
+			status:          payments.FakeSucceeded,
+			wantReservation: domain.ReservationConfirmed,
+			wantPayment:     domain.PaymentPaid,
+		},
+		{
+			name:            "failed releases hold",
+			status:          payments.FakeCanceled,
+			wantReservation: domain.ReservationCanceled,
+			wantPayment:     domain.PaymentFailed,
+		},
+		{
+			name:              "paid after expiry is refunded",
+			expireFirst:       true,
+			status:            payments.FakeSucceeded,
+			wantReservation:   domain.ReservationCanceled,
+			wantPayment:       domain.PaymentRefunded,
+			wantRefundedByPSP: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, provider := newTestService(t)
+			created, payment := bookWithDeposit(t, service, storage)
+			if tt.expireFirst {
+				expired := storage.payments[payment.ID]
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				storage.payments[payment.ID] = expired
+				if err := service.ExpirePayments(context.Background()); err != nil {
+					t.Fatalf("ExpirePayments: %v", err)
+				}
+			}
+
+			notify(t, service, payment, tt.status)
+
+			if got := storage.reservation(t, created.ID).Status; got != tt.wantReservation {
+				t.Errorf("reservation status = %s, want %s", got, tt.wantReservation)
+			}
+			if got := storage.payments[payment.ID].Status; got != tt.wantPayment {
+				t.Errorf("payment status = %s, want %s", got, tt.wantPayment)
+			}
+			if got := provider.Refunded(payment.ExternalID); got != tt.wantRefundedByPSP {
+				t.Errorf("refunded at provider = %v, want %v", got, tt.wantRefundedByPSP)
+			}
+		})
+	}
+}
+
+func TestPaymentWebhookIsIdempotent(t *testing.T) {
+	service, storage, provider := newTestService(t)
+	created, payment := bookWithDeposit(t, service, storage)
+
+	notify(t, service, payment, payments.FakeSucceeded)
+	notify(t, service, payment, payments.FakeSucceeded)
+
+	if got := storage.reservation(t, created.ID).Status; got != domain.ReservationConfirmed {
+		t.Errorf("reservation status = %s, want confirmed", got)
+	}
+	if provider.Refunded(payment.ExternalID) {
+		t.Error("repeated notification refunded the deposit")
+	}
+}
+
+func TestCancelReservationRefundsPaidDeposit(t *testing.T) {
+	service, storage, provider := newTestService(t)
+	created, payment := bookWithDeposit(t, service, storage)
+	notify(t, service, payment, payments.FakeSucceeded)
+
+	canceled, err := service.CancelReservation(context.Background(), testGuest, created.ID)
+	if err != nil {
+		t.Fatalf("CancelReservation: %v", err)
+	}
+	if canceled.Status != domain.ReservationCanceled {
+		t.Errorf("reservation status = %s, want canceled", canceled.Status)
+	}
+	if got := storage.payments[payment.ID].Status; got != domain.PaymentRefunded {
+		t.Errorf("payment status = %s, want refunded", got)
+	}
+	if !provider.Refunded(payment.ExternalID) {
+		t.Error("deposit was not refunded at the provider")
+	}
+}
+
+func TestLateCancellationRetainsDeposit(t *testing.T) {
+	service, storage, provider := newTestService(t)
+	created, payment := bookWithDeposit(t, service, storage)
+	policy := storage.policies[testRestaurant]
+	policy.RefundWindow = 72 * time.Hour
+	storage.policies[testRestaurant] = policy
+	notify(t, service, payment, payments.FakeSucceeded)
+
+	if _, err := service.CancelReservation(context.Background(), testGuest, created.ID); err != nil {
+		t.Fatalf("CancelReservation: %v", err)
+	}
+	if got := storage.payments[payment.ID].Status; got != domain.PaymentPaid {
+		t.Errorf("payment status = %s, want paid", got)
+	}
+	if provider.Refunded(payment.ExternalID) {
+		t.Error("deposit refunded after late cancellation")
+	}
+}
+
+// depositPolicy требует депозит с компаний от четырех гостей и с броней в часы пик 20:00–23:00.
+func depositPolicy() domain.BookingPolicy {
+	return domain.BookingPolicy{
+		RestaurantID:     testRestaurant,
+		MaxDuration:      4 * time.Hour,
+		DepositPartySize: 4,
+		DepositPerGuest:  50000,
+		PeakStart:        20 * time.Hour,
+		PeakEnd:          23 * time.Hour,
+	}
+}
+
+func TestUpdateReservationRejectsUncoveredDeposit(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	storage.policies[testRestaurant] = depositPolicy()
+	start := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour).Add(12 * time.Hour)
+	stored := storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  2,
+	}, "t1")
+
+	_, err := service.UpdateReservation(context.Background(), dto.ReservationDTO{ID: "res-1", Capacity: 4, Version: stored.Version})
+	if !errors.Is(err, domain.ErrDepositIncrease) {
+		t.Fatalf("err = %v, want ErrDepositIncrease", err)
+	}
+	if got := storage.reservation(t, "res-1"); got.Capacity != 2 || got.Version != stored.Version {
+		t.Errorf("update was applied: capacity %d, version %d", got.Capacity, got.Version)
+	}
+}
+
+func TestUpdateReservationWithinPaidDeposit(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	created, payment := bookWithDeposit(t, service, storage)
+	notify(t, service, payment, payments.FakeSucceeded)
+	stored := storage.reservation(t, created.ID)
+
+	if _, err := service.UpdateReservation(context.Background(), dto.ReservationDTO{
+		ID:       created.ID,
+		Capacity: 4,
+		Contacts: dto.ContactsDTO{Name: "Anna"},
+		Version:  stored.Version,
+	}); err != nil {
+		t.Fatalf("UpdateReservation: %v", err)
+	}
+	if got := storage.reservation(t, created.ID); got.Contacts.Name != "Anna" || got.Status != domain.ReservationConfirmed {
+		t.Errorf("contacts %+v, status %s; want Anna and confirmed", got.Contacts, got.Status)
+	}
+}
+
+func TestRescheduleIntoPeakRejectsUncoveredDeposit(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	storage.policies[testRestaurant] = depositPolicy()
+	start := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour).Add(12 * time.Hour)
+	storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  2,
+	}, "t1")
+
+	_, err := service.RescheduleReservation(context.Background(), testGuest, dto.RescheduleDTO{
+		ReservationID: "res-1",
+		StartTime:     start.Add(8 * time.Hour),
+		EndTime:       start.Add(9 * time.Hour),
+	})
+	if !errors.Is(err, domain.ErrDepositIncrease) {
+		t.Fatalf("err = %v, want ErrDepositIncrease", err)
+	}
+	if got := storage.reservation(t, "res-1"); !got.StartTime.Equal(start) {
+		t.Errorf("start = %s, want %s kept", got.StartTime, start)
+	}
+}
+
+func TestEventBookingsRequireDeposit(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		approve bool
+	}{
+		{name: "manager booking", userID: testManager},
+		{name: "approved guest booking", userID: testGuest, approve: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, _ := newTestService(t)
+			storage.addTable("t1", 4)
+			storage.addTable("t2", 4)
+			storage.policies[testRestaurant] = depositPolicy()
+			start := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour).Add(12 * time.Hour)
+
+			created, err := service.BookEvent(context.Background(), dto.EventBookingDTO{
+				UserID:       tt.userID,
+				RestaurantID: testRestaurant,
+				StartTime:    start,
+				EndTime:      start.Add(3 * time.Hour),
+				Capacity:     6,
+			})
+			if err != nil {
+				t.Fatalf("BookEvent: %v", err)
+			}
+			if tt.approve {
+				if created.Status != domain.ReservationWait || created.Payment != nil {
+					t.Fatalf("status %s, payment %v; want pending approval without payment", created.Status, created.Payment)
+				}
+				if created, err = service.DecideEvent(context.Background(), testManager, created.ID, true); err != nil {
+					t.Fatalf("DecideEvent: %v", err)
+				}
+			}
+			if created.Status != domain.ReservationPendingPayment || created.Payment == nil {
+				t.Fatalf("status %s, payment %v; want pending deposit", created.Status, created.Payment)
+			}
+			payment := storage.payments[created.Payment.ID]
+			if payment.Amount != 300000 {
+				t.Errorf("deposit = %d, want 300000", payment.Amount)
+			}
+
+			notify(t, service, payment, payments.FakeSucceeded)
+			if got := storage.reservation(t, created.ID).Status; got != domain.ReservationConfirmed {
+				t.Errorf("status after payment %s, want confirmed", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+)
+
+func (u UserService) GetBookingPolicy(ctx context.Context, restaurantId string) (_ dto.BookingPolicyDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetBookingPolicy")
+	defer func() { endSpan(span, err) }()
+
+	policy, err := u.bookingPolicy(ctx, restaurantId)
+	if err != nil {
+		return dto.BookingPolicyDTO{}, err
+	}
+	return *fromBookingPolicyDomain(&policy), nil
+}
+
+func (u UserService) UpdateBookingPolicy(ctx context.Context, userId string, policyDto dto.BookingPolicyDTO) (_ dto.BookingPolicyDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateBookingPolicy")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, policyDto.RestaurantID, userId); err != nil {
+		return policyDto, err
+	}
+	policy := toBookingPolicyDomain(&policyDto)
+	if err = policy.Validate(); err != nil {
+		return policyDto, err
+	}
+	before, err := u.bookingPolicy(ctx, policy.RestaurantID)
+	if err != nil {
+		return policyDto, err
+	}
+	if err = u.storage.SaveBookingPolicy(ctx, policy); err != nil {
+		return policyDto, err
+	}
+	result := fromBookingPolicyDomain(policy)
+	u.auditRestaurant(ctx, userId, domain.AuditUpdatePolicy, policy.RestaurantID, fromBookingPolicyDomain(&before), result)
+	u.logger.Info("Booking policy updated", "restaurant_id", policy.RestaurantID, "user_id", userId)
+	return *result, nil
+}
+
+// bookingPolicy возвращает правила ресторана, а если они не заданы — правила по умолчанию.
+func (u UserService) bookingPolicy(ctx context.Context, restaurantId string) (domain.BookingPolicy, error) {
+	policy, err := u.storage.GetBookingPolicy(ctx, restaurantId)
+	if err != nil {
+		return domain.BookingPolicy{}, err
+	}
+	if policy == nil {
+		return domain.DefaultBookingPolicy(restaurantId), nil
+	}
+	return *policy, nil
+}
+
+// requireManager проверяет, что пользователь управляет рестораном.
+func (u UserService) requireManager(ctx context.Context, restaurantId string, userId string) error {
+	role, err := u.storage.GetStaffRole(ctx, restaurantId, userId)
+	if err != nil {
+		return err
+	}
+	if role != domain.StaffRoleManager {
+		return domain.ErrNotRestaurantManager
+	}
+	return nil
+}
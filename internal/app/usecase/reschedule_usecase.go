@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (u UserService) RescheduleReservation(ctx context.Context, userId string, reschedule dto.RescheduleDTO) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.RescheduleReservation")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.storage.GetReservationForId(ctx, reschedule.ReservationID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if reservation == nil {
+		return dto.ReservationDTO{}, domain.ErrReservationNotFound
+	}
+	if reservation.UserID != userId {
+		return dto.ReservationDTO{}, domain.ErrReservationForbidden
+	}
+	if reservation.Status == domain.ReservationCanceled {
+		return dto.ReservationDTO{}, domain.ErrReservationCanceled
+	}
+	if !reservation.SeatedAt.IsZero() {
+		return dto.ReservationDTO{}, domain.ErrAlreadySeated
+	}
+
+	before, err := u.reservationWithTables(ctx, reservation)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+
+	tables := make([]*domain.Table, 0, len(reschedule.Table))
+	if len(reschedule.Table) == 0 {
+		current, err := u.storage.GetTablesByReservationID(ctx, reservation.ID)
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+		for i := range current {
+			tables = append(tables, &current[i])
+		}
+	} else {
+		for _, id := range reschedule.Table {
+			tables = append(tables, &domain.Table{ID: id})
+		}
+	}
+
+	freedStart, freedEnd := reservation.StartTime, reservation.EndTime
+	reservation.StartTime = reschedule.StartTime
+	reservation.EndTime = reschedule.EndTime
+
+	loc, err := u.restaurantLocation(ctx, reservation.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	policy, err := u.bookingPolicy(ctx, reservation.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = reservation.CheckPolicy(policy, len(tables), time.Now(), loc); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = u.checkOpen(ctx, reservation.RestaurantID, reservation.StartTime, reservation.EndTime, loc); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	// Перенос в часы пик может требовать депозит, которого не было при создании брони.
+	if err = u.checkDepositCovered(ctx, reservation, policy, loc); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	_, tablesDomain, err := u.checkGuestCapacity(ctx, tables, reservation.Capacity)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	tableIds := map[string]string{}
+	for _, t := range tablesDomain {
+		if t.RestaurantID != reservation.RestaurantID {
+			return dto.ReservationDTO{}, domain.ErrTableNotFound.Withf("table %s not found in restaurant %s", t.ID, reservation.RestaurantID)
+		}
+		tableIds[uuid.New().String()] = t.ID
+	}
+
+	// Проверка доступности и замена столиков идут в одной транзакции хранилища:
+	// при конфликте бронь остается на прежнем времени и столиках.
+	if err = u.storage.RescheduleReservation(ctx, reservation, tableIds, policy.TurnoverBuffer); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.logger.Info("Reservation rescheduled", "reservation_id", reservation.ID, "user_id", userId)
+	u.offerFreedSlot(ctx, reservation.RestaurantID, freedStart, freedEnd)
+
+	result := fromReservationDomain(reservation)
+	for _, t := range tablesDomain {
+		result.Table = append(result.Table, *fromTableDomain(t))
+	}
+	u.audit(ctx, domain.AuditEntry{
+		RestaurantID: reservation.RestaurantID,
+		EntityType:   domain.AuditEntityReservation,
+		EntityID:     reservation.ID,
+		Action:       domain.AuditReschedule,
+		ActorID:      userId,
+	}, before, result)
+	return *result, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRescheduleTimeOnlyKeepsTables(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 2)
+	storage.addTable("t2", 2)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  4,
+	}, "t1", "t2")
+
+	moved, err := service.RescheduleReservation(context.Background(), testGuest, dto.RescheduleDTO{
+		ReservationID: "res-1",
+		StartTime:     start.Add(2 * time.Hour),
+		EndTime:       start.Add(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("RescheduleReservation: %v", err)
+	}
+	if len(moved.Table) != 2 || moved.Table[0].ID != "t1" || moved.Table[1].ID != "t2" {
+		t.Errorf("tables = %+v, want t1 and t2", moved.Table)
+	}
+	stored := storage.reservation(t, "res-1")
+	if !stored.StartTime.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("stored start = %s, want %s", stored.StartTime, start.Add(2*time.Hour))
+	}
+	if got := storage.reservationTables["res-1"]; len(got) != 2 {
+		t.Errorf("stored tables = %v, want 2 tables", got)
+	}
+}
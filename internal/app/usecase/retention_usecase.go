@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// ArchiveReservations архивирует брони, закончившиеся больше retention назад, и стирает контакты
+// гостя в них. Нулевой retention отключает архивацию.
+func (u UserService) ArchiveReservations(ctx context.Context, retention time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ArchiveReservations")
+	defer func() { endSpan(span, err) }()
+
+	if retention <= 0 {
+		return nil
+	}
+	now := time.Now()
+	archived, err := u.storage.ArchiveReservations(ctx, now.Add(-retention), now)
+	if err != nil {
+		return err
+	}
+	if archived > 0 {
+		u.logger.Info("Reservations archived", "count", archived, "retention", retention.String())
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (u UserService) GetSchedule(ctx context.Context, restaurantId string, from, to time.Time) (_ dto.ScheduleDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetSchedule")
+	defer func() { endSpan(span, err) }()
+
+	loc, err := u.restaurantLocation(ctx, restaurantId)
+	if err != nil {
+		return dto.ScheduleDTO{}, err
+	}
+	schedule, err := u.storage.GetSchedule(ctx, restaurantId, wallClock(from, loc), wallClock(to, loc))
+	if err != nil {
+		return dto.ScheduleDTO{}, err
+	}
+	result := dto.ScheduleDTO{
+		Weekly:      make([]dto.OpeningHoursDTO, 0, len(schedule.Weekly)),
+		SpecialDays: make([]dto.SpecialDayDTO, 0, len(schedule.SpecialDays)),
+		Closures:    make([]dto.ClosureDTO, 0, len(schedule.Closures)),
+	}
+	for _, h := range schedule.Weekly {
+		result.Weekly = append(result.Weekly, *fromOpeningHoursDomain(&h))
+	}
+	for _, d := range schedule.SpecialDays {
+		result.SpecialDays = append(result.SpecialDays, *fromSpecialDayDomain(&d))
+	}
+	for _, c := range schedule.Closures {
+		result.Closures = append(result.Closures, *fromClosureDomain(&c))
+	}
+	return result, nil
+}
+
+func (u UserService) UpdateOpeningHours(ctx context.Context, userId string, restaurantId string, hoursDto []dto.OpeningHoursDTO) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateOpeningHours")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return err
+	}
+	now := time.Now()
+	current, err := u.storage.GetSchedule(ctx, restaurantId, now, now)
+	if err != nil {
+		return err
+	}
+	before := make([]dto.OpeningHoursDTO, 0, len(current.Weekly))
+	for _, h := range current.Weekly {
+		before = append(before, *fromOpeningHoursDomain(&h))
+	}
+	hours := make([]domain.OpeningHours, 0, len(hoursDto))
+	for _, h := range hoursDto {
+		hours = append(hours, *toOpeningHoursDomain(&h, restaurantId))
+	}
+	if err = u.storage.ReplaceOpeningHours(ctx, restaurantId, hours); err != nil {
+		return err
+	}
+	after := make([]dto.OpeningHoursDTO, 0, len(hours))
+	for _, h := range hours {
+		after = append(after, *fromOpeningHoursDomain(&h))
+	}
+	u.auditRestaurant(ctx, userId, domain.AuditUpdateHours, restaurantId,
+		map[string]any{"opening_hours": before}, map[string]any{"opening_hours": after})
+	return nil
+}
+
+func (u UserService) SaveSpecialDay(ctx context.Context, userId string, dayDto dto.SpecialDayDTO) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.SaveSpecialDay")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, dayDto.RestaurantID, userId); err != nil {
+		return err
+	}
+	day := toSpecialDayDomain(&dayDto)
+	before, err := u.specialDaySnapshot(ctx, day.RestaurantID, day.Date)
+	if err != nil {
+		return err
+	}
+	if err = u.storage.SaveSpecialDay(ctx, day); err != nil {
+		return err
+	}
+	u.auditRestaurant(ctx, userId, domain.AuditSaveSpecialDay, day.RestaurantID,
+		before, auditKeyed("special_days", day.Date.Format(time.DateOnly), fromSpecialDayDomain(day)))
+	return nil
+}
+
+func (u UserService) DeleteSpecialDay(ctx context.Context, userId string, restaurantId string, date time.Time) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteSpecialDay")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return err
+	}
+	before, err := u.specialDaySnapshot(ctx, restaurantId, date)
+	if err != nil {
+		return err
+	}
+	ok, err := u.storage.DeleteSpecialDay(ctx, restaurantId, date)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrSpecialDayNotFound
+	}
+	u.auditRestaurant(ctx, userId, domain.AuditDeleteSpecialDay, restaurantId, before, nil)
+	return nil
+}
+
+func (u UserService) CreateClosure(ctx context.Context, userId string, closureDto dto.ClosureDTO) (_ dto.ClosureDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateClosure")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, closureDto.RestaurantID, userId); err != nil {
+		return closureDto, err
+	}
+	closure := toClosureDomain(&closureDto)
+	closure.ID = uuid.New().String()
+	if err = u.storage.CreateClosure(ctx, closure); err != nil {
+		return closureDto, err
+	}
+	result := fromClosureDomain(closure)
+	u.auditRestaurant(ctx, userId, domain.AuditCreateClosure, closure.RestaurantID, nil, auditKeyed("closures", closure.ID, result))
+	return *result, nil
+}
+
+func (u UserService) DeleteClosure(ctx context.Context, userId string, restaurantId string, closureId string) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteClosure")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return err
+	}
+	closure, err := u.storage.DeleteClosure(ctx, restaurantId, closureId)
+	if err != nil {
+		return err
+	}
+	if closure == nil {
+		return domain.ErrClosureNotFound
+	}
+	u.auditRestaurant(ctx, userId, domain.AuditDeleteClosure, restaurantId, auditKeyed("closures", closure.ID, fromClosureDomain(closure)), nil)
+	return nil
+}
+
+// specialDaySnapshot возвращает снимок особого дня для журнала аудита или nil, если на дату его нет.
+func (u UserService) specialDaySnapshot(ctx context.Context, restaurantId string, date time.Time) (any, error) {
+	schedule, err := u.storage.GetSchedule(ctx, restaurantId, date, date)
+	if err != nil {
+		return nil, err
+	}
+	key := date.Format(time.DateOnly)
+	for _, d := range schedule.SpecialDays {
+		if d.Date.Format(time.DateOnly) == key {
+			return auditKeyed("special_days", key, fromSpecialDayDomain(&d)), nil
+		}
+	}
+	return nil, nil
+}
+
+// checkOpen проверяет, что ресторан работает весь интервал [start, end).
+func (u UserService) checkOpen(ctx context.Context, restaurantId string, start, end time.Time, loc *time.Location) error {
+	open, err := u.isOpen(ctx, restaurantId, start, end, loc)
+	if err != nil {
+		return err
+	}
+	if !open {
+		return domain.ErrRestaurantClosed
+	}
+	return nil
+}
+
+func (u UserService) isOpen(ctx context.Context, restaurantId string, start, end time.Time, loc *time.Location) (bool, error) {
+	schedule, err := u.storage.GetSchedule(ctx, restaurantId, start, end)
+	if err != nil {
+		return false, err
+	}
+	return schedule.IsOpen(start, end, loc), nil
+}
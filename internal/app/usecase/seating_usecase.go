@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// defaultWalkInDuration — сколько держится столик за гостями без брони, если хост не указал время
+// и в правилах ресторана нет максимальной длительности.
+const defaultWalkInDuration = 2 * time.Hour
+
+// requireStaff проверяет, что пользователь работает в ресторане менеджером или хостом.
+func (u UserService) requireStaff(ctx context.Context, restaurantId string, userId string) error {
+	role, err := u.storage.GetStaffRole(ctx, restaurantId, userId)
+	if err != nil {
+		return err
+	}
+	if role != domain.StaffRoleManager && role != domain.StaffRoleHost {
+		return domain.ErrNotRestaurantStaff
+	}
+	return nil
+}
+
+func (u UserService) SeatWalkIn(ctx context.Context, userId string, walkIn dto.WalkInDTO) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.SeatWalkIn")
+	defer func() { endSpan(span, err) }()
+	restaurantLabel := unknownRestaurant
+	defer func() {
+		if err != nil {
+			u.metrics.ReservationFailed(restaurantLabel, failReason(err))
+		}
+	}()
+
+	if err = u.requireStaff(ctx, walkIn.RestaurantID, userId); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	// Персонал есть только у существующего ресторана
+	restaurantLabel = walkIn.RestaurantID
+	policy, err := u.bookingPolicy(ctx, walkIn.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	duration := time.Duration(walkIn.DurationMinutes) * time.Minute
+	if duration == 0 {
+		duration = defaultWalkInDuration
+		if policy.MaxDuration > 0 && policy.MaxDuration < duration {
+			duration = policy.MaxDuration
+		}
+	}
+	if policy.MaxPartySize > 0 && walkIn.Capacity > policy.MaxPartySize {
+		return dto.ReservationDTO{}, domain.ErrPartyTooLarge.Withf("в одной брони может быть не более %d гостей", policy.MaxPartySize)
+	}
+
+	tables := make([]*domain.Table, 0, len(walkIn.Table))
+	for _, id := range walkIn.Table {
+		tables = append(tables, &domain.Table{ID: id})
+	}
+	_, tablesDomain, err := u.checkGuestCapacity(ctx, tables, walkIn.Capacity)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+
+	// Гости уже пришли, поэтому правила о заблаговременности брони и часах работы не применяются,
+	// но занятый бронью или заблокированный столик посадить нельзя.
+	now := time.Now()
+	tableIds := map[string]string{}
+	for _, t := range tablesDomain {
+		if t.RestaurantID != walkIn.RestaurantID {
+			return dto.ReservationDTO{}, domain.ErrTableNotFound.Withf("table %s not found in restaurant %s", t.ID, walkIn.RestaurantID)
+		}
+		ok, err := u.storage.IsTableAvailable(ctx, t.ID, now, now.Add(duration))
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+		if !ok {
+			return dto.ReservationDTO{}, domain.ErrTableNotAvailable.Withf("table %s not available", t.ID)
+		}
+		tableIds[uuid.New().String()] = t.ID
+	}
+
+	reservation := &domain.Reservation{
+		ID:           uuid.New().String(),
+		RestaurantID: walkIn.RestaurantID,
+		StartTime:    now,
+		EndTime:      now.Add(duration),
+		Status:       domain.ReservationConfirmed,
+		Contacts: domain.Contacts{
+			Name:  walkIn.Contacts.Name,
+			Phone: walkIn.Contacts.Phone,
+		},
+		Capacity: walkIn.Capacity,
+		Source:   domain.ReservationSourceWalkIn,
+		SeatedAt: now,
+	}
+	if _, err = u.storage.CreateReservation(ctx, reservation, tableIds, 0); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.metrics.ReservationCreated(walkIn.RestaurantID)
+	u.auditReservation(ctx, userId, domain.AuditCreate, nil, reservation)
+	u.logger.Info("Walk-in seated", "reservation_id", reservation.ID, "restaurant_id", walkIn.RestaurantID, "user_id", userId)
+
+	result := fromReservationDomain(reservation)
+	for _, t := range tablesDomain {
+		result.Table = append(result.Table, *fromTableDomain(t))
+	}
+	return *result, nil
+}
+
+func (u UserService) SeatReservation(ctx context.Context, userId string, reservationId string) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.SeatReservation")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.staffReservation(ctx, userId, reservationId)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	before := *reservation
+	if err = reservation.Seat(time.Now()); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = u.saveReservation(ctx, reservation); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.auditReservation(ctx, userId, domain.AuditSeat, &before, reservation)
+	u.logger.Info("Reservation seated", "reservation_id", reservation.ID, "user_id", userId)
+	return u.reservationWithTables(ctx, reservation)
+}
+
+func (u UserService) MarkLeft(ctx context.Context, userId string, reservationId string) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.MarkLeft")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.staffReservation(ctx, userId, reservationId)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	before := *reservation
+	plannedEnd := reservation.EndTime
+	if err = reservation.Leave(time.Now()); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = u.saveReservation(ctx, reservation); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.auditReservation(ctx, userId, domain.AuditLeave, &before, reservation)
+	u.logger.Info("Guests left", "reservation_id", reservation.ID, "user_id", userId)
+	// Гости ушли раньше — остаток их времени можно предложить листу ожидания.
+	if plannedEnd.After(reservation.EndTime) {
+		u.offerFreedSlot(ctx, reservation.RestaurantID, reservation.EndTime, plannedEnd)
+	}
+	return u.reservationWithTables(ctx, reservation)
+}
+
+// MarkNoShow отмечает неявку гостя: бронь отменяется, депозит не возвращается,
+// а оставшееся время брони предлагается листу ожидания.
+func (u UserService) MarkNoShow(ctx context.Context, userId string, reservationId string) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.MarkNoShow")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.staffReservation(ctx, userId, reservationId)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	before := *reservation
+	now := time.Now()
+	if err = reservation.MarkNoShow(now); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = u.saveReservation(ctx, reservation); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.auditReservation(ctx, userId, domain.AuditNoShow, &before, reservation)
+	u.logger.Info("Guest no-show", "reservation_id", reservation.ID, "user_id", userId)
+	u.settleDeposit(ctx, reservation)
+	if reservation.EndTime.After(now) {
+		u.offerFreedSlot(ctx, reservation.RestaurantID, now, reservation.EndTime)
+	}
+	return u.reservationWithTables(ctx, reservation)
+}
+
+// staffReservation загружает бронь и проверяет, что пользователь работает в ее ресторане.
+func (u UserService) staffReservation(ctx context.Context, userId string, reservationId string) (*domain.Reservation, error) {
+	reservation, err := u.storage.GetReservationForId(ctx, reservationId)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, domain.ErrReservationNotFound
+	}
+	if err := u.requireStaff(ctx, reservation.RestaurantID, userId); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (u UserService) reservationWithTables(ctx context.Context, reservation *domain.Reservation) (dto.ReservationDTO, error) {
+	tables, err := u.storage.GetTablesByReservationID(ctx, reservation.ID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	result := fromReservationDomain(reservation)
+	for _, t := range tables {
+		result.Table = append(result.Table, *fromTableDomain(&t))
+	}
+	return *result, nil
+}
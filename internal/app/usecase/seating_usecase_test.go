@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSeatReservationReturnsReservationTables(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 2)
+	storage.addTable("t2", 4)
+	start := time.Now().Add(10 * time.Minute)
+	storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  5,
+	}, "t1", "t2")
+
+	seated, err := service.SeatReservation(context.Background(), testManager, "res-1")
+	if err != nil {
+		t.Fatalf("SeatReservation: %v", err)
+	}
+	if len(seated.Table) != 2 {
+		t.Fatalf("got %d tables, want 2: %+v", len(seated.Table), seated.Table)
+	}
+	for i, id := range []string{"t1", "t2"} {
+		if seated.Table[i].ID != id {
+			t.Errorf("tables[%d] = %q, want %q", i, seated.Table[i].ID, id)
+		}
+	}
+	if storage.reservation(t, "res-1").SeatedAt.IsZero() {
+		t.Error("seated_at is not stored")
+	}
+}
+
+func TestMarkNoShowReturnsReservationTables(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 2)
+	storage.addTable("t2", 4)
+	start := time.Now().Add(-20 * time.Minute)
+	storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  5,
+	}, "t1", "t2")
+
+	marked, err := service.MarkNoShow(context.Background(), testManager, "res-1")
+	if err != nil {
+		t.Fatalf("MarkNoShow: %v", err)
+	}
+	if len(marked.Table) != 2 {
+		t.Fatalf("got %d tables, want 2: %+v", len(marked.Table), marked.Table)
+	}
+	stored := storage.reservation(t, "res-1")
+	if !stored.NoShow || stored.Status != domain.ReservationCanceled {
+		t.Errorf("stored reservation = %s, no_show %v; want canceled no-show", stored.Status, stored.NoShow)
+	}
+}
+
+func TestGuestReliabilityLimitedToRestaurantGuests(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	start := time.Now().Add(-48 * time.Hour).Truncate(time.Hour)
+	storage.addReservation(domain.Reservation{
+		ID:           "elsewhere",
+		UserID:       "stranger",
+		RestaurantID: "restaurant-2",
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Status:       domain.ReservationConfirmed,
+		NoShow:       true,
+	})
+	storage.addReservation(domain.Reservation{
+		ID:        "here",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		SeatedAt:  start,
+	})
+
+	reliability, err := service.GetGuestReliability(context.Background(), testManager, testRestaurant, testGuest)
+	if err != nil {
+		t.Fatalf("GetGuestReliability: %v", err)
+	}
+	if reliability.Visits != 1 {
+		t.Errorf("visits = %d, want 1", reliability.Visits)
+	}
+	_, err = service.GetGuestReliability(context.Background(), testManager, testRestaurant, "stranger")
+	if !errors.Is(err, domain.ErrGuestNotFound) {
+		t.Errorf("err = %v, want ErrGuestNotFound for a guest of another restaurant", err)
+	}
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (u UserService) CreateReservationSeries(ctx context.Context, reservationDto dto.ReservationDTO, recurrence dto.RecurrenceDTO) (_ dto.ReservationSeriesDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateReservationSeries")
+	defer func() { endSpan(span, err) }()
+
+	loc, err := u.restaurantLocation(ctx, reservationDto.RestaurantID)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	series := &domain.ReservationSeries{
+		ID:           uuid.New().String(),
+		UserID:       reservationDto.UserID,
+		RestaurantID: reservationDto.RestaurantID,
+		Rule:         toRecurrenceRuleDomain(&recurrence),
+		CreatedAt:    time.Now(),
+	}
+	occurrences, err := series.Rule.Occurrences(reservationDto.StartTime, reservationDto.EndTime, loc)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	if err = u.storage.CreateReservationSeries(ctx, series); err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+
+	// Каждое повторение бронируется как обычная бронь со всеми проверками. Занятое
+	// повторение не отменяет серию, а попадает в список конфликтов. Любая другая ошибка
+	// отменяет всю серию, чтобы гость, получивший ошибку, не остался с частью броней.
+	result := fromReservationSeriesDomain(series)
+	for _, occurrence := range occurrences {
+		occurrenceDto := reservationDto
+		occurrenceDto.StartTime = occurrence[0]
+		occurrenceDto.EndTime = occurrence[1]
+		occurrenceDto.SeriesID = series.ID
+		created, err := u.CreateReservation(ctx, occurrenceDto)
+		if err != nil {
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) {
+				u.abandonSeries(ctx, series, result.Reservations)
+				return dto.ReservationSeriesDTO{}, err
+			}
+			result.Conflicts = append(result.Conflicts, dto.OccurrenceConflictDTO{
+				StartTime: occurrence[0],
+				EndTime:   occurrence[1],
+				Code:      domainErr.Code,
+				Message:   domainErr.Error(),
+			})
+			continue
+		}
+		result.Reservations = append(result.Reservations, created)
+	}
+
+	if len(result.Reservations) == 0 {
+		if err = u.storage.DeleteReservationSeries(ctx, series.ID); err != nil {
+			return dto.ReservationSeriesDTO{}, err
+		}
+		first := result.Conflicts[0]
+		return dto.ReservationSeriesDTO{}, domain.ErrSeriesConflict.Withf("none of %d reservations could be booked, first: %s", len(occurrences), first.Message)
+	}
+	u.logger.Info("Reservation series created", "series_id", series.ID, "created", len(result.Reservations), "conflicts", len(result.Conflicts))
+	return *result, nil
+}
+
+// abandonSeries отменяет уже созданные брони серии created, закрывает их депозиты и удаляет серию.
+// Вызывается, когда серию не удалось создать до конца. Ошибки только логируются: гостю
+// возвращается исходная ошибка, из-за которой серия не создана.
+func (u UserService) abandonSeries(ctx context.Context, series *domain.ReservationSeries, created []dto.ReservationDTO) {
+	// Исходной ошибкой могла быть отмена запроса, а брони все равно нужно освободить.
+	ctx = context.WithoutCancel(ctx)
+	canceled, err := u.storage.CancelSeriesReservations(ctx, series.ID, time.Now(), 0)
+	if err != nil {
+		u.logger.Error("Failed to cancel reservations of abandoned series", "series_id", series.ID, "error", err)
+		return
+	}
+	statuses := make(map[string]string, len(created))
+	for _, r := range created {
+		statuses[r.ID] = r.Status
+	}
+	for _, r := range canceled {
+		u.auditStatusChange(ctx, domain.AuditActorSystem, domain.AuditCancel, r, statuses[r.ID])
+		u.metrics.ReservationCanceled(r.RestaurantID)
+		u.settleDeposit(ctx, r)
+	}
+	if err = u.storage.DeleteReservationSeries(ctx, series.ID); err != nil {
+		u.logger.Error("Failed to delete abandoned series", "series_id", series.ID, "error", err)
+		return
+	}
+	u.logger.Warn("Reservation series abandoned", "series_id", series.ID, "canceled", len(canceled))
+}
+
+func (u UserService) GetReservationSeries(ctx context.Context, userId string, seriesId string) (_ dto.ReservationSeriesDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetReservationSeries")
+	defer func() { endSpan(span, err) }()
+
+	series, err := u.ownSeries(ctx, userId, seriesId)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	return u.seriesWithReservations(ctx, series)
+}
+
+func (u UserService) CancelReservationSeries(ctx context.Context, userId string, seriesId string) (_ dto.ReservationSeriesDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CancelReservationSeries")
+	defer func() { endSpan(span, err) }()
+
+	series, err := u.ownSeries(ctx, userId, seriesId)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	policy, err := u.bookingPolicy(ctx, series.RestaurantID)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	current, err := u.storage.GetSeriesReservations(ctx, series.ID)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	before := make(map[string]*domain.Reservation, len(current))
+	for _, r := range current {
+		before[r.ID] = r
+	}
+	canceled, err := u.storage.CancelSeriesReservations(ctx, series.ID, time.Now(), policy.CancellationCutoff)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	for _, r := range canceled {
+		if previous, ok := before[r.ID]; ok {
+			u.auditReservation(ctx, userId, domain.AuditCancel, previous, r)
+		}
+		u.metrics.ReservationCanceled(r.RestaurantID)
+		u.settleDeposit(ctx, r)
+		u.offerFreedSlot(ctx, r.RestaurantID, r.StartTime, r.EndTime)
+	}
+	u.logger.Info("Reservation series canceled", "series_id", series.ID, "canceled", len(canceled), "user_id", userId)
+	return u.seriesWithReservations(ctx, series)
+}
+
+// ownSeries загружает серию и проверяет, что она принадлежит пользователю.
+func (u UserService) ownSeries(ctx context.Context, userId string, seriesId string) (*domain.ReservationSeries, error) {
+	series, err := u.storage.GetReservationSeries(ctx, seriesId)
+	if err != nil {
+		return nil, err
+	}
+	if series.UserID != userId {
+		return nil, domain.ErrSeriesForbidden
+	}
+	return series, nil
+}
+
+func (u UserService) seriesWithReservations(ctx context.Context, series *domain.ReservationSeries) (dto.ReservationSeriesDTO, error) {
+	reservations, err := u.storage.GetSeriesReservations(ctx, series.ID)
+	if err != nil {
+		return dto.ReservationSeriesDTO{}, err
+	}
+	result := fromReservationSeriesDomain(series)
+	for _, r := range reservations {
+		reservation, err := u.reservationWithTables(ctx, r)
+		if err != nil {
+			return dto.ReservationSeriesDTO{}, err
+		}
+		result.Reservations = append(result.Reservations, reservation)
+	}
+	return *result, nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCreateReservationSeriesRollsBackOnStorageError(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	storage.policies[testRestaurant] = domain.BookingPolicy{
+		RestaurantID:     testRestaurant,
+		MaxDuration:      2 * time.Hour,
+		DepositPartySize: 4,
+		DepositPerGuest:  50000,
+	}
+	storage.createLimit = 2
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	_, err := service.CreateReservationSeries(context.Background(), dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "t1"}},
+		Capacity:     4,
+	}, dto.RecurrenceDTO{Frequency: domain.RecurrenceWeekly, Count: 4})
+	if err == nil {
+		t.Fatal("CreateReservationSeries succeeded, want storage error")
+	}
+
+	if len(storage.series) != 0 {
+		t.Errorf("series kept after failure: %v", storage.series)
+	}
+	if len(storage.reservations) != 2 {
+		t.Fatalf("%d reservations stored, want 2 created before the error", len(storage.reservations))
+	}
+	for id, r := range storage.reservations {
+		if r.Status != domain.ReservationCanceled || r.SeriesID != "" {
+			t.Errorf("reservation %s: status %s, series %q; want canceled one-off", id, r.Status, r.SeriesID)
+		}
+		if entries := storage.auditEntries(id); len(entries) != 2 || entries[1].Action != domain.AuditCancel {
+			t.Errorf("reservation %s audit = %+v, want create and cancel", id, entries)
+		}
+	}
+	for _, p := range storage.payments {
+		if p.Status != domain.PaymentCanceled {
+			t.Errorf("payment %s status %s, want canceled", p.ID, p.Status)
+		}
+	}
+}
+
+func TestCreateReservationSeriesKeepsDomainConflicts(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	storage.addReservation(domain.Reservation{
+		ID:        "busy",
+		StartTime: start.AddDate(0, 0, 7),
+		EndTime:   start.AddDate(0, 0, 7).Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  2,
+	}, "t1")
+
+	series, err := service.CreateReservationSeries(context.Background(), dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "t1"}},
+		Capacity:     2,
+	}, dto.RecurrenceDTO{Frequency: domain.RecurrenceWeekly, Count: 3})
+	if err != nil {
+		t.Fatalf("CreateReservationSeries: %v", err)
+	}
+	if len(series.Reservations) != 2 || len(series.Conflicts) != 1 {
+		t.Fatalf("%d reservations, %d conflicts; want 2 and 1", len(series.Reservations), len(series.Conflicts))
+	}
+	if series.Conflicts[0].Code != domain.ErrTableNotAvailable.Code {
+		t.Errorf("conflict code %s, want %s", series.Conflicts[0].Code, domain.ErrTableNotAvailable.Code)
+	}
+	if _, ok := storage.series[series.ID]; !ok {
+		t.Error("series not stored")
+	}
+}
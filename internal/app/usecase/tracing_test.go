@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"booking_system/internal/infrastructure/adapters/tracing"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	spanExporter *tracetest.InMemoryExporter
+	spanProvider *sdktrace.TracerProvider
+	spanOnce     sync.Once
+)
+
+// recordSpans подключает глобальный провайдер трассировки с экспортером в памяти
+// и очищает спаны предыдущих тестов. Провайдер ставится один раз: tracer пакета
+// привязывается к первому глобальному провайдеру.
+func recordSpans(t *testing.T) {
+	t.Helper()
+	spanOnce.Do(func() {
+		spanExporter = tracetest.NewInMemoryExporter()
+		spanProvider = tracing.NewTracerProvider(spanExporter, "booking_system_test", 1)
+	})
+	spanExporter.Reset()
+}
+
+// recordedSpans выгружает накопленные спаны.
+func recordedSpans(t *testing.T) tracetest.SpanStubs {
+	t.Helper()
+	if err := spanProvider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush spans: %v", err)
+	}
+	return spanExporter.GetSpans()
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %q not recorded, got %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func TestCreateReservationSpanIsChildOfCaller(t *testing.T) {
+	recordSpans(t)
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "Controller.CreateBooking")
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	_, err := service.CreateReservation(ctx, dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "t1"}},
+		Capacity:     2,
+	})
+	parent.End()
+	if err != nil {
+		t.Fatalf("CreateReservation: %v", err)
+	}
+
+	spans := recordedSpans(t)
+	controller := findSpan(t, spans, "Controller.CreateBooking")
+	usecase := findSpan(t, spans, "UserService.CreateReservation")
+	if usecase.Parent.SpanID() != controller.SpanContext.SpanID() {
+		t.Errorf("use case span parent = %s, want controller span %s", usecase.Parent.SpanID(), controller.SpanContext.SpanID())
+	}
+	if usecase.SpanContext.TraceID() != controller.SpanContext.TraceID() {
+		t.Errorf("use case span is in another trace")
+	}
+	if usecase.Status.Code != codes.Unset {
+		t.Errorf("status = %v, want unset", usecase.Status.Code)
+	}
+}
+
+func TestFailedUseCaseSpanRecordsError(t *testing.T) {
+	recordSpans(t)
+	service, _, _ := newTestService(t)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	_, err := service.CreateReservation(context.Background(), dto.ReservationDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Table:        []dto.TableDTO{{ID: "missing"}},
+		Capacity:     2,
+	})
+	if !errors.Is(err, domain.ErrTableNotFound) {
+		t.Fatalf("err = %v, want ErrTableNotFound", err)
+	}
+
+	span := findSpan(t, recordedSpans(t), "UserService.CreateReservation")
+	if span.Status.Code != codes.Error {
+		t.Errorf("status = %v, want error", span.Status.Code)
+	}
+	if len(span.Events) == 0 || span.Events[0].Name != "exception" {
+		t.Errorf("error is not recorded as span event: %+v", span.Events)
+	}
+}
@@ -3,11 +3,12 @@ package usecase
 import (
 	"booking_system/cmd/providers/middelware"
 	"booking_system/internal/app/ports"
+	"booking_system/internal/domain"
 	"booking_system/internal/dto"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"log/slog"
@@ -22,19 +23,30 @@ type UserService struct {
 	logger   *slog.Logger
 	tokenBot string
 	jwt      *middelware.Jwt
+	metrics  ports.IMetrics
+	events   ports.IEventPublisher
+	payments ports.IPaymentProvider
+	offerTTL time.Duration // Сколько гость из листа ожидания может думать над предложением
 }
 
-func New(storage ports.IStorage, logger *slog.Logger, t string, jwt *middelware.Jwt) UserService {
+func New(storage ports.IStorage, logger *slog.Logger, t string, jwt *middelware.Jwt, metrics ports.IMetrics, events ports.IEventPublisher, payments ports.IPaymentProvider, offerTTL time.Duration) UserService {
 	return UserService{
 		storage:  storage,
 		logger:   logger,
 		tokenBot: t,
 		jwt:      jwt,
+		metrics:  metrics,
+		events:   events,
+		payments: payments,
+		offerTTL: offerTTL,
 	}
 }
 
-func (u UserService) AuthUser(dto dto.UserDTO) (dto.UserDTO, string, error) {
-	ok, user, err := u.storage.CheckUserForTelegram(dto.TelegramID)
+func (u UserService) AuthUser(ctx context.Context, dto dto.UserDTO) (_ dto.UserDTO, _ string, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.AuthUser")
+	defer func() { endSpan(span, err) }()
+
+	ok, user, err := u.storage.CheckUserForTelegram(ctx, dto.TelegramID)
 	if err != nil {
 		return dto, "", err
 	}
@@ -49,7 +61,7 @@ func (u UserService) AuthUser(dto dto.UserDTO) (dto.UserDTO, string, error) {
 		domainUser := toUserDomain(&dto)
 		uuid := uuid.New().String()
 		domainUser.ID = uuid
-		newUser, err := u.storage.CreateUser(*domainUser)
+		newUser, err := u.storage.CreateUser(ctx, *domainUser)
 		if err != nil {
 			return dto, "", err
 		}
@@ -62,15 +74,18 @@ func (u UserService) AuthUser(dto dto.UserDTO) (dto.UserDTO, string, error) {
 	}
 }
 
-func (u UserService) GetReservationForDate(date *time.Time) ([]dto.ReservationDTO, error) {
-	reservation, err := u.storage.GetReservationsForDate(*date)
+func (u UserService) GetReservationForDate(ctx context.Context, date *time.Time) (_ []dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetReservationForDate")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.storage.GetReservationsForDate(ctx, *date)
 	if err != nil {
 		return nil, err
 	}
 
 	var reservations []dto.ReservationDTO
 	for _, val := range reservation {
-		tablesDomain, err := u.storage.GetTablesByReservationID(val.ID)
+		tablesDomain, err := u.storage.GetTablesByReservationID(ctx, val.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -85,47 +100,92 @@ func (u UserService) GetReservationForDate(date *time.Time) ([]dto.ReservationDT
 
 }
 
-func (u UserService) CreateReservation(dtoReservation dto.ReservationDTO) (dto.ReservationDTO, error) {
-	u.logger.Debug("Create Reservation len table first %v", len(dtoReservation.Table))
+func (u UserService) CreateReservation(ctx context.Context, dtoReservation dto.ReservationDTO) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateReservation")
+	defer func() { endSpan(span, err) }()
+
+	return u.createReservation(ctx, dtoReservation, nil)
+}
+
+// createReservation создает бронь. Если claim не nil, бронь создается по предложению
+// листа ожидания: столики придержаны для этой записи, поэтому доступность проверяется
+// в хранилище вместе с закрытием предложения, без отдельной предварительной проверки.
+func (u UserService) createReservation(ctx context.Context, dtoReservation dto.ReservationDTO, claim *domain.WaitlistEntry) (_ dto.ReservationDTO, err error) {
+	u.logger.Debug("Create Reservation", "tables", len(dtoReservation.Table))
 	domainReservation, tables := toReservationDomain(&dtoReservation)
-	ok, err := domainReservation.CheckDate()
+	restaurantLabel := unknownRestaurant
+	defer func() {
+		if err != nil {
+			u.metrics.ReservationFailed(restaurantLabel, failReason(err))
+		}
+	}()
+
+	loc, err := u.restaurantLocation(ctx, domainReservation.RestaurantID)
 	if err != nil {
 		return dtoReservation, err
 	}
-	if !ok {
-		return dtoReservation, errors.New("invalid date")
+	restaurantLabel = domainReservation.RestaurantID
+	policy, err := u.bookingPolicy(ctx, domainReservation.RestaurantID)
+	if err != nil {
+		return dtoReservation, err
+	}
+	if err = domainReservation.CheckPolicy(policy, len(tables), time.Now(), loc); err != nil {
+		return dtoReservation, err
 	}
-	if len(tables) > 4 {
-		return dtoReservation, errors.New("too many tables")
+	if err = u.checkOpen(ctx, domainReservation.RestaurantID, domainReservation.StartTime, domainReservation.EndTime, loc); err != nil {
+		return dtoReservation, err
 	}
 
-	_, tablesDomain, err := u.checkGuestCapacity(tables, domainReservation.Capacity)
+	_, tablesDomain, err := u.checkGuestCapacity(ctx, tables, domainReservation.Capacity)
 	if err != nil {
 		return dtoReservation, err
 	}
 
+	// Буфер на уборку расширяет интервал проверки, чтобы соседние брони не шли впритык.
+	checkStart := domainReservation.StartTime.Add(-policy.TurnoverBuffer)
+	checkEnd := domainReservation.EndTime.Add(policy.TurnoverBuffer)
 	tableIds := map[string]string{}
-	u.logger.Debug("len tableIds: %v", len(tables))
-	u.logger.Info("Table", tables)
+	u.logger.Debug("Checking tables", "count", len(tables))
+	u.logger.Info("Table", "tables", tables)
 	for _, t := range tables {
-		u.logger.Debug("LoopTable: %s", t.ID)
-		TableOk, err := u.storage.IsTableAvailable(t.ID, domainReservation.StartTime, domainReservation.EndTime)
-		if err != nil {
-			return dtoReservation, err
-		}
-		if !TableOk {
-			return dtoReservation, errors.New(fmt.Sprintf("table %s not available", t.ID))
+		u.logger.Debug("LoopTable", "table_id", t.ID)
+		if claim == nil {
+			TableOk, err := u.storage.IsTableAvailable(ctx, t.ID, checkStart, checkEnd)
+			if err != nil {
+				return dtoReservation, err
+			}
+			if !TableOk {
+				return dtoReservation, domain.ErrTableNotAvailable.Withf("table %s not available", t.ID)
+			}
 		}
 		tableIds[uuid.New().String()] = t.ID
 	}
 
 	domainReservation.ID = uuid.New().String()
+	// Бронь с депозитом придерживает столики, пока гость не оплатит его.
+	deposit := policy.Deposit(*domainReservation, loc)
+	if deposit > 0 {
+		domainReservation.Status = domain.ReservationPendingPayment
+	}
 
-	_, err = u.storage.CreateReservation(domainReservation, tableIds)
+	if claim != nil {
+		err = u.storage.ClaimWaitlistOffer(ctx, claim, domainReservation, tableIds, policy.TurnoverBuffer, policy.MaxActiveBookings)
+	} else {
+		_, err = u.storage.CreateReservation(ctx, domainReservation, tableIds, policy.MaxActiveBookings)
+	}
 	if err != nil {
-		u.logger.Error("Failed to create reservation: %v", err)
+		u.logger.Error("Failed to create reservation", "error", err)
 		return dtoReservation, err
 	}
+	u.auditReservation(ctx, domainReservation.UserID, domain.AuditCreate, nil, domainReservation)
+	var payment *domain.Payment
+	if deposit > 0 {
+		payment, err = u.collectDeposit(ctx, domainReservation, deposit, policy)
+		if err != nil {
+			return dtoReservation, err
+		}
+	}
+	u.metrics.ReservationCreated(domainReservation.RestaurantID)
 	dtoTables := make([]dto.TableDTO, 0, len(tables))
 	for _, table := range tablesDomain {
 		dtoTable := *fromTableDomain(table)
@@ -133,11 +193,17 @@ func (u UserService) CreateReservation(dtoReservation dto.ReservationDTO) (dto.R
 	}
 	dtoReservationResult := fromReservationDomain(domainReservation)
 	dtoReservationResult.Table = dtoTables
+	if payment != nil {
+		dtoReservationResult.Payment = fromPaymentDomain(payment)
+	}
 	return *dtoReservationResult, nil
 }
 
-func (u UserService) GetUserReservations(userId string) ([]dto.ReservationDTO, error) {
-	reservations, err := u.storage.GetUserReservationsUser(userId)
+func (u UserService) GetUserReservations(ctx context.Context, userId string) (_ []dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserReservations")
+	defer func() { endSpan(span, err) }()
+
+	reservations, err := u.storage.GetUserReservationsUser(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +212,7 @@ func (u UserService) GetUserReservations(userId string) ([]dto.ReservationDTO, e
 	for _, val := range reservations {
 		var tables []dto.TableDTO
 		reservationDto := fromReservationDomain(val)
-		table, err := u.storage.GetTablesByReservationID(val.ID)
+		table, err := u.storage.GetTablesByReservationID(ctx, val.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -160,8 +226,11 @@ func (u UserService) GetUserReservations(userId string) ([]dto.ReservationDTO, e
 
 }
 
-func (u UserService) GetUserReservationsDate(date *time.Time, userId string) ([]dto.ReservationDTO, error) {
-	reservations, err := u.storage.GetUserReservationsUserForDate(*date, userId)
+func (u UserService) GetUserReservationsDate(ctx context.Context, date *time.Time, userId string) (_ []dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserReservationsDate")
+	defer func() { endSpan(span, err) }()
+
+	reservations, err := u.storage.GetUserReservationsUserForDate(ctx, *date, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +239,7 @@ func (u UserService) GetUserReservationsDate(date *time.Time, userId string) ([]
 	for _, val := range reservations {
 		var tables []dto.TableDTO
 		reservationDto := fromReservationDomain(val)
-		table, err := u.storage.GetTablesByReservationID(val.ID)
+		table, err := u.storage.GetTablesByReservationID(ctx, val.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -183,7 +252,9 @@ func (u UserService) GetUserReservationsDate(date *time.Time, userId string) ([]
 	return reservationsDto, nil
 }
 
-func (u UserService) ValidateTelegramHash(telegramHash string, data map[string]string) (bool, error) {
+func (u UserService) ValidateTelegramHash(ctx context.Context, telegramHash string, data map[string]string) (_ bool, err error) {
+	_, span := tracer.Start(ctx, "UserService.ValidateTelegramHash")
+	defer func() { endSpan(span, err) }()
 
 	requiredFields := []string{"id", "first_name", "auth_date", "hash"}
 	for _, field := range requiredFields {
@@ -221,42 +292,125 @@ func (u UserService) ValidateTelegramHash(telegramHash string, data map[string]s
 	return strings.ToLower(expectedHash) == strings.ToLower(telegramHash), nil
 }
 
-func (u UserService) GetReservationForId(reservationId string) (dto.ReservationDTO, error) {
-	domainReservation, err := u.storage.GetReservationForId(reservationId)
+func (u UserService) GetReservationForId(ctx context.Context, reservationId string) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetReservationForId")
+	defer func() { endSpan(span, err) }()
+
+	domainReservation, err := u.storage.GetReservationForId(ctx, reservationId)
 	if err != nil {
 		return dto.ReservationDTO{}, err
 	}
-	u.logger.Debug("domainReservation: %v", domainReservation)
+	u.logger.Debug("domainReservation", "reservation", domainReservation)
 	if domainReservation == nil {
-		return dto.ReservationDTO{}, errors.New("reservation not found")
+		return dto.ReservationDTO{}, domain.ErrReservationNotFound
+	}
+	result := fromReservationDomain(domainReservation)
+	if err = u.withPayment(ctx, result); err != nil {
+		return dto.ReservationDTO{}, err
 	}
-	return *fromReservationDomain(domainReservation), nil
+	return *result, nil
 }
 
-func (u UserService) UpdateReservation(dto dto.ReservationDTO) (bool, error) {
-	domainReservation, _ := toReservationDomain(&dto)
-	table, err := u.storage.GetTablesByReservationID(domainReservation.ID)
+// UpdateReservation сохраняет изменения брони гостя. reservationDto.Version — версия, которую видел
+// клиент: если бронь с тех пор изменили, возвращается ErrReservationModified. Нулевая версия
+// означает последнюю сохраненную.
+func (u UserService) UpdateReservation(ctx context.Context, reservationDto dto.ReservationDTO) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateReservation")
+	defer func() { endSpan(span, err) }()
+
+	update, _ := toReservationDomain(&reservationDto)
+	before, err := u.storage.GetReservationForId(ctx, update.ID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if before == nil {
+		return dto.ReservationDTO{}, domain.ErrReservationNotFound
+	}
+	if update.Version == 0 {
+		update.Version = before.Version
+	}
+	if update.Version != before.Version {
+		return dto.ReservationDTO{}, domain.ErrReservationModified
+	}
+	// Менять можно только бронь, которая еще не началась, как и при отмене или переносе.
+	if before.Status == domain.ReservationCanceled {
+		return dto.ReservationDTO{}, domain.ErrReservationCanceled
+	}
+	if !before.SeatedAt.IsZero() {
+		return dto.ReservationDTO{}, domain.ErrAlreadySeated
+	}
+	if before.NoShow || !time.Now().Before(before.StartTime) {
+		return dto.ReservationDTO{}, domain.ErrCancellationClosed
+	}
+	// Гость меняет только контакты и число гостей, остальное берется из сохраненной брони.
+	domainReservation := *before
+	domainReservation.Contacts = update.Contacts
+	domainReservation.Capacity = update.Capacity
+
+	table, err := u.storage.GetTablesByReservationID(ctx, domainReservation.ID)
 	if err != nil {
-		return false, err
+		return dto.ReservationDTO{}, err
 	}
 
 	_, _, err = u.checkGuestCapacityMax(table, domainReservation.Capacity)
 	if err != nil {
-		return false, err
+		return dto.ReservationDTO{}, err
 	}
-	ok, err := u.storage.UpdateReservation(domainReservation)
+	policy, err := u.bookingPolicy(ctx, domainReservation.RestaurantID)
 	if err != nil {
-		return ok, err
+		return dto.ReservationDTO{}, err
 	}
-	if !ok {
-		return ok, errors.New("reservation not found")
+	if policy.MaxPartySize > 0 && domainReservation.Capacity > policy.MaxPartySize {
+		return dto.ReservationDTO{}, domain.ErrPartyTooLarge.Withf("в одной брони может быть не более %d гостей", policy.MaxPartySize)
+	}
+	loc, err := u.restaurantLocation(ctx, domainReservation.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	// Большая компания может требовать депозит, которого не было при создании брони.
+	if err = u.checkDepositCovered(ctx, &domainReservation, policy, loc); err != nil {
+		return dto.ReservationDTO{}, err
 	}
+	if err = u.saveReservation(ctx, &domainReservation); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.auditReservation(ctx, domainReservation.UserID, domain.AuditUpdate, before, &domainReservation)
+
+	result := fromReservationDomain(&domainReservation)
+	for _, t := range table {
+		result.Table = append(result.Table, *fromTableDomain(&t))
+	}
+	return *result, nil
+}
 
-	return ok, nil
+// saveReservation сохраняет загруженную и измененную бронь. Если с момента загрузки
+// бронь изменил другой запрос, возвращает ErrReservationModified.
+func (u UserService) saveReservation(ctx context.Context, reservation *domain.Reservation) error {
+	ok, err := u.storage.UpdateReservation(ctx, reservation)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrReservationModified
+	}
+	return nil
 }
 
-func (u UserService) GetTableForReservationDate(date time.Time, restaurantId string) ([]dto.AvaibleTableDTO, error) {
-	domainTables, err := u.storage.GetTablesWithAvailability(restaurantId, date)
+func (u UserService) GetTableForReservationDate(ctx context.Context, date time.Time, restaurantId string) (_ []dto.AvaibleTableDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetTableForReservationDate")
+	defer func() { endSpan(span, err) }()
+
+	loc, err := u.restaurantLocation(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	date = wallClock(date, loc)
+	domainTables, err := u.storage.GetTablesWithAvailability(ctx, restaurantId, date)
+	if err != nil {
+		return nil, err
+	}
+	// В нерабочее время свободных столиков нет. Минута нужна, чтобы момент закрытия не считался рабочим.
+	open, err := u.isOpen(ctx, restaurantId, date, date.Add(time.Minute), loc)
 	if err != nil {
 		return nil, err
 	}
@@ -264,11 +418,12 @@ func (u UserService) GetTableForReservationDate(date time.Time, restaurantId str
 	for _, t := range domainTables {
 		dtoTable := *fromTableDomain(&t.Table)
 		avaibleTable := dto.AvaibleTableDTO{
-			IsAvaible: t.IsAvailable,
-			TableDTO:  dtoTable,
+			IsAvaible:  t.IsAvailable && open,
+			IsOccupied: t.IsOccupied,
+			TableDTO:   dtoTable,
 		}
 		avaibleTablesDto = append(avaibleTablesDto, avaibleTable)
 	}
-	u.logger.Debug("domainTables: %v", avaibleTablesDto)
+	u.logger.Debug("domainTables", "tables", avaibleTablesDto)
 	return avaibleTablesDto, nil
 }
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUpdateReservationChangesOnlyContactsAndCapacity(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	stored := storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  2,
+		SeriesID:  "series-1",
+		ZoneID:    "zone-1",
+	}, "t1")
+
+	updated, err := service.UpdateReservation(context.Background(), dto.ReservationDTO{
+		ID:        "res-1",
+		UserID:    "someone-else",
+		StartTime: start.Add(5 * time.Hour),
+		EndTime:   start.Add(6 * time.Hour),
+		Status:    domain.ReservationCanceled,
+		Capacity:  3,
+		Contacts:  dto.ContactsDTO{Name: "Anna", Phone: "+79990000001"},
+		Version:   stored.Version,
+	})
+	if err != nil {
+		t.Fatalf("UpdateReservation: %v", err)
+	}
+
+	got := storage.reservation(t, "res-1")
+	if got.Capacity != 3 || got.Contacts.Name != "Anna" {
+		t.Errorf("capacity %d, contacts %+v; want 3 and Anna", got.Capacity, got.Contacts)
+	}
+	if !got.StartTime.Equal(start) || got.Status != domain.ReservationConfirmed || got.UserID != testGuest {
+		t.Errorf("non-editable fields changed: start %s, status %s, user %s", got.StartTime, got.Status, got.UserID)
+	}
+	if got.SeriesID != "series-1" || got.ZoneID != "zone-1" {
+		t.Errorf("series %q, zone %q; want them kept", got.SeriesID, got.ZoneID)
+	}
+	if updated.Version != stored.Version+1 || got.Version != updated.Version {
+		t.Errorf("version = %d (stored %d), want %d", updated.Version, got.Version, stored.Version+1)
+	}
+}
+
+func TestUpdateReservationRejectsStaleVersion(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	stored := storage.addReservation(domain.Reservation{
+		ID:        "res-1",
+		UserID:    testGuest,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Status:    domain.ReservationConfirmed,
+		Capacity:  2,
+		Version:   3,
+	}, "t1")
+
+	_, err := service.UpdateReservation(context.Background(), dto.ReservationDTO{ID: "res-1", Capacity: 3, Version: stored.Version - 1})
+	if !errors.Is(err, domain.ErrReservationModified) {
+		t.Fatalf("err = %v, want ErrReservationModified", err)
+	}
+	if got := storage.reservation(t, "res-1"); got.Capacity != 2 || got.Version != 3 {
+		t.Errorf("stale update was applied: capacity %d, version %d", got.Capacity, got.Version)
+	}
+}
+
+func TestUpdateReservationRejectsClosedReservations(t *testing.T) {
+	now := time.Now()
+	future := now.Add(24 * time.Hour).Truncate(time.Hour)
+	past := now.Add(-30 * time.Minute)
+	tests := []struct {
+		name        string
+		reservation domain.Reservation
+		want        error
+	}{
+		{
+			name:        "canceled",
+			reservation: domain.Reservation{StartTime: future, Status: domain.ReservationCanceled},
+			want:        domain.ErrReservationCanceled,
+		},
+		{
+			name:        "seated",
+			reservation: domain.Reservation{StartTime: past, Status: domain.ReservationConfirmed, SeatedAt: past},
+			want:        domain.ErrAlreadySeated,
+		},
+		{
+			name:        "started",
+			reservation: domain.Reservation{StartTime: past, Status: domain.ReservationConfirmed},
+			want:        domain.ErrCancellationClosed,
+		},
+		{
+			name:        "no-show",
+			reservation: domain.Reservation{StartTime: past, Status: domain.ReservationConfirmed, NoShow: true},
+			want:        domain.ErrCancellationClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, _ := newTestService(t)
+			storage.addTable("t1", 4)
+			tt.reservation.ID = "res-1"
+			tt.reservation.UserID = testGuest
+			tt.reservation.EndTime = tt.reservation.StartTime.Add(2 * time.Hour)
+			tt.reservation.Capacity = 2
+			stored := storage.addReservation(tt.reservation, "t1")
+
+			_, err := service.UpdateReservation(context.Background(), dto.ReservationDTO{ID: "res-1", Capacity: 3, Version: stored.Version})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if got := storage.reservation(t, "res-1"); got.Capacity != 2 || got.Version != stored.Version {
+				t.Errorf("update was applied: capacity %d, version %d", got.Capacity, got.Version)
+			}
+		})
+	}
+}
+
+func TestFailedBookingMetricsUseKnownRestaurantsOnly(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	tests := []struct {
+		name string
+		book func(service UserService) error
+		want string
+	}{
+		{
+			name: "reservation in unknown restaurant",
+			book: func(service UserService) error {
+				_, err := service.CreateReservation(context.Background(), dto.ReservationDTO{RestaurantID: "random-id", UserID: testGuest})
+				return err
+			},
+			want: unknownRestaurant + "/" + domain.ErrRestaurantNotFound.Code,
+		},
+		{
+			name: "reservation rejected by policy",
+			book: func(service UserService) error {
+				_, err := service.CreateReservation(context.Background(), dto.ReservationDTO{
+					RestaurantID: testRestaurant,
+					UserID:       testGuest,
+					StartTime:    past,
+					EndTime:      past.Add(time.Hour),
+					Table:        []dto.TableDTO{{ID: "t1"}},
+				})
+				return err
+			},
+			want: testRestaurant + "/" + domain.ErrStartTimeInPast.Code,
+		},
+		{
+			name: "walk-in in unknown restaurant",
+			book: func(service UserService) error {
+				_, err := service.SeatWalkIn(context.Background(), testManager, dto.WalkInDTO{RestaurantID: "random-id"})
+				return err
+			},
+			want: unknownRestaurant + "/" + domain.ErrNotRestaurantStaff.Code,
+		},
+		{
+			name: "event in unknown restaurant",
+			book: func(service UserService) error {
+				_, err := service.BookEvent(context.Background(), dto.EventBookingDTO{RestaurantID: "random-id", UserID: testGuest})
+				return err
+			},
+			want: unknownRestaurant + "/" + domain.ErrRestaurantNotFound.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, _ := newTestService(t)
+			storage.addTable("t1", 4)
+			metrics := &memMetrics{}
+			service.metrics = metrics
+
+			if err := tt.book(service); err == nil {
+				t.Fatal("booking succeeded, want error")
+			}
+			if len(metrics.failed) != 1 || metrics.failed[0] != tt.want {
+				t.Errorf("failed metrics = %v, want [%s]", metrics.failed, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPolicyLimitsActiveBookings(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	book := func(day int) error {
+		_, err := service.CreateReservation(context.Background(), dto.ReservationDTO{
+			UserID:       testGuest,
+			RestaurantID: testRestaurant,
+			StartTime:    start.AddDate(0, 0, day),
+			EndTime:      start.AddDate(0, 0, day).Add(time.Hour),
+			Table:        []dto.TableDTO{{ID: "t1"}},
+			Capacity:     2,
+		})
+		return err
+	}
+
+	for day := 0; day < domain.DefaultMaxActiveBookings; day++ {
+		if err := book(day); err != nil {
+			t.Fatalf("booking %d: %v", day, err)
+		}
+	}
+	if err := book(domain.DefaultMaxActiveBookings); !errors.Is(err, domain.ErrTooManyReservations) {
+		t.Fatalf("err = %v, want ErrTooManyReservations", err)
+	}
+}
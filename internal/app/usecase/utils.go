@@ -2,29 +2,78 @@ package usecase
 
 import (
 	"booking_system/internal/domain"
+	"context"
 	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
 )
 
-func (u UserService) checkGuestCapacity(tables []*domain.Table, reservationCapacity int) (int, []*domain.Table, error) {
+var tracer = otel.Tracer("booking_system/usecase")
+
+// endSpan завершает спан сценария, отмечая его ошибкой, если сценарий завершился неудачно.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// unknownRestaurant — метка ресторана в метриках неудачных броней, пока ресторан не найден.
+// ID из URL попадает в метку только после этого, чтобы запросы к случайным ID не порождали новые серии.
+const unknownRestaurant = "unknown"
+
+// failReason возвращает причину неудачи для бизнес-метрик: код доменной ошибки или "internal".
+func failReason(err error) string {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return "internal"
+}
+
+// restaurantLocation возвращает часовой пояс ресторана.
+func (u UserService) restaurantLocation(ctx context.Context, restaurantId string) (*time.Location, error) {
+	restaurant, err := u.storage.GetRestaurant(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := restaurant.Location()
+	if err != nil {
+		return nil, fmt.Errorf("restaurant %s has invalid timezone %q: %w", restaurantId, restaurant.Timezone, err)
+	}
+	return loc, nil
+}
+
+// wallClock трактует показания часов t как время в поясе loc, отбрасывая исходный пояс.
+// Так дата без смещения из запроса понимается как местное время ресторана.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+func (u UserService) checkGuestCapacity(ctx context.Context, tables []*domain.Table, reservationCapacity int) (int, []*domain.Table, error) {
 	var maxCapacity int
-	u.logger.Debug("Checking guest capacity %v", reservationCapacity)
-	u.logger.Debug("len table %v", len(tables))
+	u.logger.Debug("Checking guest capacity", "capacity", reservationCapacity)
+	u.logger.Debug("Checking tables", "count", len(tables))
 	tabelesDomain := make([]*domain.Table, 0, len(tables))
 	for _, t := range tables {
-		table, err := u.storage.GetTable(t.ID)
+		table, err := u.storage.GetTable(ctx, t.ID)
 		if err != nil {
-			u.logger.Error("Get table error %v", err)
+			u.logger.Error("Get table error", "error", err)
 			return 0, nil, err
 		}
-		u.logger.Debug("table %v", table)
-		u.logger.Debug("Checking table capacity %v", table.Capacity)
+		u.logger.Debug("Table", "table", table)
+		u.logger.Debug("Checking table capacity", "capacity", table.Capacity)
 		tabelesDomain = append(tabelesDomain, table)
 		maxCapacity += table.Capacity
 	}
-	u.logger.Debug("Checking guest capacity max %v", maxCapacity)
+	u.logger.Debug("Checking guest capacity max", "capacity", maxCapacity)
 
 	if reservationCapacity > maxCapacity {
-		return 0, nil, errors.New("capacity exceeded")
+		return 0, nil, domain.ErrCapacityExceeded
 	}
 
 	return maxCapacity, tabelesDomain, nil
@@ -32,17 +81,17 @@ func (u UserService) checkGuestCapacity(tables []*domain.Table, reservationCapac
 
 func (u UserService) checkGuestCapacityMax(tables []domain.Table, reservationCapacity int) (int, []*domain.Table, error) {
 	var maxCapacity int
-	u.logger.Debug("Checking guest capacity %v", reservationCapacity)
-	u.logger.Debug("len table %v", len(tables))
+	u.logger.Debug("Checking guest capacity", "capacity", reservationCapacity)
+	u.logger.Debug("Checking tables", "count", len(tables))
 	tabelesDomain := make([]*domain.Table, 0, len(tables))
 	for _, t := range tables {
 		maxCapacity += t.Capacity
 		tabelesDomain = append(tabelesDomain, &t)
 	}
-	u.logger.Debug("Checking guest capacity max %v", maxCapacity)
+	u.logger.Debug("Checking guest capacity max", "capacity", maxCapacity)
 
 	if reservationCapacity > maxCapacity {
-		return 0, nil, errors.New("capacity exceeded")
+		return 0, nil, domain.ErrCapacityExceeded
 	}
 
 	return maxCapacity, tabelesDomain, nil
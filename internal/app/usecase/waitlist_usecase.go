@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// EventWaitlistOffered — тип события о предложении столика из листа ожидания.
+const EventWaitlistOffered = "waitlist.offered"
+
+func (u UserService) JoinWaitlist(ctx context.Context, entryDto dto.WaitlistEntryDTO) (_ dto.WaitlistEntryDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.JoinWaitlist")
+	defer func() { endSpan(span, err) }()
+
+	entry := toWaitlistEntryDomain(&entryDto)
+	loc, err := u.restaurantLocation(ctx, entry.RestaurantID)
+	if err != nil {
+		return entryDto, err
+	}
+	policy, err := u.bookingPolicy(ctx, entry.RestaurantID)
+	if err != nil {
+		return entryDto, err
+	}
+	reservation := entry.Reservation()
+	if err = reservation.CheckPolicy(policy, 1, time.Now(), loc); err != nil {
+		return entryDto, err
+	}
+	if err = u.checkOpen(ctx, entry.RestaurantID, entry.StartTime, entry.EndTime, loc); err != nil {
+		return entryDto, err
+	}
+
+	entry.ID = uuid.New().String()
+	entry.Status = domain.WaitlistWaiting
+	entry.CreatedAt = time.Now()
+	if err = u.storage.CreateWaitlistEntry(ctx, entry); err != nil {
+		return entryDto, err
+	}
+	u.logger.Info("Guest joined waitlist", "entry_id", entry.ID, "restaurant_id", entry.RestaurantID)
+	return *fromWaitlistEntryDomain(entry), nil
+}
+
+func (u UserService) GetUserWaitlist(ctx context.Context, userId string) (_ []dto.WaitlistEntryDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserWaitlist")
+	defer func() { endSpan(span, err) }()
+
+	entries, err := u.storage.GetUserWaitlist(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.WaitlistEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, *fromWaitlistEntryDomain(&e))
+	}
+	return result, nil
+}
+
+func (u UserService) LeaveWaitlist(ctx context.Context, userId string, entryId string) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.LeaveWaitlist")
+	defer func() { endSpan(span, err) }()
+
+	entry, err := u.ownWaitlistEntry(ctx, userId, entryId)
+	if err != nil {
+		return err
+	}
+	if entry.Status != domain.WaitlistWaiting && entry.Status != domain.WaitlistOffered {
+		return domain.ErrWaitlistClosed
+	}
+	canceled := *entry
+	canceled.Status = domain.WaitlistCanceled
+	ok, err := u.storage.UpdateWaitlistEntry(ctx, &canceled, entry.Status)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrWaitlistClosed
+	}
+	// Отказ от предложения освобождает придержанные столики для следующих в очереди.
+	if entry.Status == domain.WaitlistOffered {
+		u.offerFreedSlot(ctx, entry.RestaurantID, entry.StartTime, entry.EndTime)
+	}
+	return nil
+}
+
+func (u UserService) ClaimWaitlistOffer(ctx context.Context, userId string, entryId string) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ClaimWaitlistOffer")
+	defer func() { endSpan(span, err) }()
+
+	entry, err := u.ownWaitlistEntry(ctx, userId, entryId)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if !entry.OfferActive(time.Now()) {
+		return dto.ReservationDTO{}, domain.ErrNoActiveOffer
+	}
+
+	reservation := entry.Reservation()
+	reservationDto := *fromReservationDomain(&reservation)
+	for _, tableID := range entry.OfferedTables {
+		reservationDto.Table = append(reservationDto.Table, dto.TableDTO{ID: tableID})
+	}
+	// Бронь создается и предложение закрывается в одной транзакции хранилища,
+	// чтобы столик не успели занять между этими шагами.
+	return u.createReservation(ctx, reservationDto, entry)
+}
+
+// ExpireWaitlist закрывает записи, время которых прошло, и передает столики
+// из просроченных предложений следующим гостям в очереди.
+func (u UserService) ExpireWaitlist(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ExpireWaitlist")
+	defer func() { endSpan(span, err) }()
+
+	now := time.Now()
+	stale, err := u.storage.ExpireStaleWaitlist(ctx, now)
+	if err != nil {
+		return err
+	}
+	if stale > 0 {
+		u.logger.Info("Stale waitlist entries expired", "count", stale)
+	}
+
+	offers, err := u.storage.GetExpiredOffers(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, offer := range offers {
+		expired := offer
+		expired.Status = domain.WaitlistExpired
+		ok, err := u.storage.UpdateWaitlistEntry(ctx, &expired, domain.WaitlistOffered)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		u.logger.Info("Waitlist offer expired", "entry_id", offer.ID)
+		u.offerFreedSlot(ctx, offer.RestaurantID, offer.StartTime, offer.EndTime)
+	}
+	return nil
+}
+
+// offerFreedSlot предлагает освободившиеся столики гостям из листа ожидания.
+// Ошибки только логируются: освобождение столика уже состоялось и не должно откатываться.
+func (u UserService) offerFreedSlot(ctx context.Context, restaurantId string, from, to time.Time) {
+	if err := u.offerWaitlist(ctx, restaurantId, from, to); err != nil {
+		u.logger.Error("Failed to offer freed slot to waitlist", "restaurant_id", restaurantId, "error", err)
+	}
+}
+
+// offerWaitlist проходит очередь ожидающих, чье время пересекается с [from, to),
+// и делает предложение каждому, для кого нашлись свободные столики. Предложенные
+// столики сразу придерживаются, поэтому следующий в очереди их уже не получит.
+func (u UserService) offerWaitlist(ctx context.Context, restaurantId string, from, to time.Time) error {
+	entries, err := u.storage.GetWaitingEntries(ctx, restaurantId, from, to)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	tables, err := u.storage.GetRestaurantTables(ctx, restaurantId)
+	if err != nil {
+		return err
+	}
+	policy, err := u.bookingPolicy(ctx, restaurantId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if !entry.StartTime.After(now) {
+			continue
+		}
+		picked, err := u.pickTables(ctx, tables, entry, policy)
+		if err != nil {
+			return err
+		}
+		if len(picked) == 0 {
+			continue
+		}
+
+		offered := entry
+		offered.Status = domain.WaitlistOffered
+		offered.OfferedTables = picked
+		offered.OfferExpiresAt = now.Add(u.offerTTL)
+		if offered.OfferExpiresAt.After(entry.StartTime) {
+			offered.OfferExpiresAt = entry.StartTime
+		}
+		ok, err := u.storage.UpdateWaitlistEntry(ctx, &offered, domain.WaitlistWaiting)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		u.logger.Info("Waitlist offer made", "entry_id", offered.ID, "tables", picked, "expires_at", offered.OfferExpiresAt)
+		u.publishOffer(ctx, offered)
+	}
+	return nil
+}
+
+// pickTables подбирает свободные столики для гостя: сначала наименьший стол, вмещающий
+// всю компанию, иначе несколько самых больших в пределах правил ресторана.
+func (u UserService) pickTables(ctx context.Context, tables []domain.Table, entry domain.WaitlistEntry, policy domain.BookingPolicy) ([]string, error) {
+	start := entry.StartTime.Add(-policy.TurnoverBuffer)
+	end := entry.EndTime.Add(policy.TurnoverBuffer)
+	free := make([]domain.Table, 0, len(tables))
+	for _, t := range tables {
+		ok, err := u.storage.IsTableAvailable(ctx, t.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			free = append(free, t)
+		}
+	}
+
+	sort.Slice(free, func(i, j int) bool { return free[i].Capacity < free[j].Capacity })
+	for _, t := range free {
+		if t.Capacity >= entry.Capacity {
+			return []string{t.ID}, nil
+		}
+	}
+
+	var (
+		picked   []string
+		capacity int
+	)
+	for i := len(free) - 1; i >= 0; i-- {
+		if policy.MaxTablesPerBooking > 0 && len(picked) == policy.MaxTablesPerBooking {
+			break
+		}
+		picked = append(picked, free[i].ID)
+		capacity += free[i].Capacity
+		if capacity >= entry.Capacity {
+			return picked, nil
+		}
+	}
+	return nil, nil
+}
+
+// publishOffer отправляет событие о предложении для доставки гостю в Telegram.
+func (u UserService) publishOffer(ctx context.Context, entry domain.WaitlistEntry) {
+	user, err := u.storage.GetUserForId(ctx, domain.User{ID: entry.UserID})
+	if err != nil || user == nil {
+		u.logger.Error("Failed to load waitlist guest", "entry_id", entry.ID, "user_id", entry.UserID, "error", err)
+		return
+	}
+	event := dto.WaitlistOfferEvent{
+		Type:         EventWaitlistOffered,
+		EntryID:      entry.ID,
+		UserID:       entry.UserID,
+		TelegramID:   user.TelegramID,
+		RestaurantID: entry.RestaurantID,
+		StartTime:    entry.StartTime,
+		EndTime:      entry.EndTime,
+		Capacity:     entry.Capacity,
+		TableIDs:     entry.OfferedTables,
+		ExpiresAt:    entry.OfferExpiresAt,
+	}
+	if err := u.events.Publish(ctx, entry.ID, event); err != nil {
+		u.logger.Error("Failed to publish waitlist offer", "entry_id", entry.ID, "error", err)
+	}
+}
+
+func (u UserService) ownWaitlistEntry(ctx context.Context, userId string, entryId string) (*domain.WaitlistEntry, error) {
+	entry, err := u.storage.GetWaitlistEntry(ctx, entryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userId {
+		return nil, domain.ErrWaitlistForbidden
+	}
+	return entry, nil
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClaimWaitlistOfferTakesHeldTable(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	storage.addOffer("entry-1", start, "t1")
+
+	created, err := service.ClaimWaitlistOffer(context.Background(), testGuest, "entry-1")
+	if err != nil {
+		t.Fatalf("ClaimWaitlistOffer: %v", err)
+	}
+	if len(created.Table) != 1 || created.Table[0].ID != "t1" {
+		t.Fatalf("tables = %+v, want held table t1", created.Table)
+	}
+	entry := storage.waitlistEntry("entry-1")
+	if entry.Status != domain.WaitlistClaimed || entry.ReservationID != created.ID {
+		t.Errorf("entry = %s/%q, want claimed and linked to %s", entry.Status, entry.ReservationID, created.ID)
+	}
+}
+
+func TestClaimWaitlistOfferKeepsOfferOnConflict(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	storage.addOffer("entry-1", start, "t1")
+	// Столик заняли в обход придержки, например бронью персонала.
+	storage.addReservation(domain.Reservation{ID: "other", UserID: "guest-2", StartTime: start, EndTime: start.Add(time.Hour), Status: domain.ReservationConfirmed}, "t1")
+
+	_, err := service.ClaimWaitlistOffer(context.Background(), testGuest, "entry-1")
+	if !errors.Is(err, domain.ErrTableNotAvailable) {
+		t.Fatalf("err = %v, want ErrTableNotAvailable", err)
+	}
+	if entry := storage.waitlistEntry("entry-1"); entry.Status != domain.WaitlistOffered || entry.ReservationID != "" {
+		t.Errorf("entry = %s/%q, want offer left untouched", entry.Status, entry.ReservationID)
+	}
+}
+
+func TestClaimWaitlistOfferRejectsClosedOffer(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	storage.addOffer("entry-1", start, "t1")
+
+	if _, err := service.ClaimWaitlistOffer(context.Background(), testGuest, "entry-1"); err != nil {
+		t.Fatalf("first claim: %v", err)
+	}
+	_, err := service.ClaimWaitlistOffer(context.Background(), testGuest, "entry-1")
+	if !errors.Is(err, domain.ErrNoActiveOffer) {
+		t.Fatalf("second claim err = %v, want ErrNoActiveOffer", err)
+	}
+	if n := len(storage.reservations); n != 1 {
+		t.Errorf("stored %d reservations, want 1", n)
+	}
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (u UserService) GetZones(ctx context.Context, restaurantId string) (_ []dto.ZoneDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetZones")
+	defer func() { endSpan(span, err) }()
+
+	zones, err := u.storage.GetZones(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.ZoneDTO, 0, len(zones))
+	for _, z := range zones {
+		result = append(result, *fromZoneDomain(&z))
+	}
+	return result, nil
+}
+
+// SaveZone создает зону, если у нее нет ID, или заменяет существующую.
+func (u UserService) SaveZone(ctx context.Context, userId string, zoneDto dto.ZoneDTO) (_ dto.ZoneDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.SaveZone")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, zoneDto.RestaurantID, userId); err != nil {
+		return zoneDto, err
+	}
+	zone := toZoneDomain(&zoneDto)
+	if err = zone.Validate(); err != nil {
+		return zoneDto, err
+	}
+	var before any
+	if zone.ID == "" {
+		zone.ID = uuid.New().String()
+	} else {
+		existing, err := u.storage.GetZone(ctx, zone.RestaurantID, zone.ID)
+		if err != nil {
+			return zoneDto, err
+		}
+		before = auditKeyed("zones", zone.ID, fromZoneDomain(existing))
+	}
+
+	tables, err := u.storage.GetRestaurantTables(ctx, zone.RestaurantID)
+	if err != nil {
+		return zoneDto, err
+	}
+	known := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		known[t.ID] = true
+	}
+	for _, id := range zone.TableIDs {
+		if !known[id] {
+			return zoneDto, domain.ErrTableNotFound.Withf("table %s not found in restaurant %s", id, zone.RestaurantID)
+		}
+	}
+
+	if err = u.storage.SaveZone(ctx, zone); err != nil {
+		return zoneDto, err
+	}
+	result := fromZoneDomain(zone)
+	u.auditRestaurant(ctx, userId, domain.AuditSaveZone, zone.RestaurantID, before, auditKeyed("zones", zone.ID, result))
+	u.logger.Info("Zone saved", "zone_id", zone.ID, "restaurant_id", zone.RestaurantID, "user_id", userId)
+	return *result, nil
+}
+
+func (u UserService) DeleteZone(ctx context.Context, userId string, restaurantId string, zoneId string) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteZone")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return err
+	}
+	zone, err := u.storage.GetZone(ctx, restaurantId, zoneId)
+	if err != nil {
+		return err
+	}
+	ok, err := u.storage.DeleteZone(ctx, restaurantId, zoneId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrZoneNotFound
+	}
+	u.auditRestaurant(ctx, userId, domain.AuditDeleteZone, restaurantId, auditKeyed("zones", zoneId, fromZoneDomain(zone)), nil)
+	u.logger.Info("Zone deleted", "zone_id", zoneId, "user_id", userId)
+	return nil
+}
+
+// BookEvent бронирует зону целиком или, если ZoneID пуст, выкупает весь ресторан.
+// Бронь гостя ждет подтверждения менеджера, если этого требует зона, и всегда при выкупе,
+// но не дольше срока подтверждения: потом ExpireEventApprovals отменяет ее и освобождает столики.
+// Бронь, созданная менеджером ресторана, подтверждается сразу. Депозит по правилам ресторана
+// запрашивается сразу у брони без подтверждения и при подтверждении у остальных.
+func (u UserService) BookEvent(ctx context.Context, event dto.EventBookingDTO) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.BookEvent")
+	defer func() { endSpan(span, err) }()
+	restaurantLabel := unknownRestaurant
+	defer func() {
+		if err != nil {
+			u.metrics.ReservationFailed(restaurantLabel, failReason(err))
+		}
+	}()
+
+	loc, err := u.restaurantLocation(ctx, event.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	restaurantLabel = event.RestaurantID
+
+	reservation := &domain.Reservation{
+		ID:           uuid.New().String(),
+		UserID:       event.UserID,
+		RestaurantID: event.RestaurantID,
+		StartTime:    event.StartTime,
+		EndTime:      event.EndTime,
+		Status:       domain.ReservationWait,
+		Capacity:     event.Capacity,
+		Contacts: domain.Contacts{
+			Name:  event.Contacts.Name,
+			Phone: event.Contacts.Phone,
+		},
+		Source: domain.ReservationSourceOnline,
+		Scope:  domain.ReservationScopeVenue,
+	}
+
+	var zone *domain.Zone
+	var tableIds []string
+	if event.ZoneID != "" {
+		zone, err = u.storage.GetZone(ctx, event.RestaurantID, event.ZoneID)
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+		reservation.Scope = domain.ReservationScopeZone
+		reservation.ZoneID = zone.ID
+		tableIds = zone.TableIDs
+	} else {
+		all, err := u.storage.GetRestaurantTables(ctx, event.RestaurantID)
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+		for _, t := range all {
+			tableIds = append(tableIds, t.ID)
+		}
+	}
+	if len(tableIds) == 0 {
+		return dto.ReservationDTO{}, domain.ErrTableNotFound.Withf("restaurant %s has no tables to book", event.RestaurantID)
+	}
+
+	policy, err := u.bookingPolicy(ctx, event.RestaurantID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	eventPolicy := policy.EventPolicy(zone)
+	if err = reservation.CheckPolicy(eventPolicy, len(tableIds), time.Now(), loc); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if err = u.checkOpen(ctx, event.RestaurantID, reservation.StartTime, reservation.EndTime, loc); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+
+	tables := make([]*domain.Table, 0, len(tableIds))
+	for _, id := range tableIds {
+		tables = append(tables, &domain.Table{ID: id})
+	}
+	_, tablesDomain, err := u.checkGuestCapacity(ctx, tables, reservation.Capacity)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	checkStart := reservation.StartTime.Add(-policy.TurnoverBuffer)
+	checkEnd := reservation.EndTime.Add(policy.TurnoverBuffer)
+	links := map[string]string{}
+	for _, t := range tablesDomain {
+		ok, err := u.storage.IsTableAvailable(ctx, t.ID, checkStart, checkEnd)
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+		if !ok {
+			return dto.ReservationDTO{}, domain.ErrTableNotAvailable.Withf("table %s not available", t.ID)
+		}
+		links[uuid.New().String()] = t.ID
+	}
+
+	role, err := u.storage.GetStaffRole(ctx, event.RestaurantID, event.UserID)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	needsApproval := reservation.Scope == domain.ReservationScopeVenue || zone.RequiresApproval
+	var deposit int64
+	if role == domain.StaffRoleManager || !needsApproval {
+		reservation.Status = domain.ReservationConfirmed
+		// Бронь с депозитом придерживает столики, пока гость не оплатит его.
+		if deposit = policy.Deposit(*reservation, loc); deposit > 0 {
+			reservation.Status = domain.ReservationPendingPayment
+		}
+	} else {
+		reservation.ApprovalExpiresAt = policy.ApprovalDeadline(*reservation, time.Now())
+	}
+
+	if _, err = u.storage.CreateReservation(ctx, reservation, links, eventPolicy.MaxActiveBookings); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	u.auditReservation(ctx, event.UserID, domain.AuditCreate, nil, reservation)
+	var payment *domain.Payment
+	if deposit > 0 {
+		payment, err = u.collectDeposit(ctx, reservation, deposit, policy)
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+	}
+	u.metrics.ReservationCreated(event.RestaurantID)
+	u.logger.Info("Event booked", "reservation_id", reservation.ID, "scope", reservation.Scope, "status", reservation.Status)
+
+	result := fromReservationDomain(reservation)
+	for _, t := range tablesDomain {
+		result.Table = append(result.Table, *fromTableDomain(t))
+	}
+	if payment != nil {
+		result.Payment = fromPaymentDomain(payment)
+	}
+	return *result, nil
+}
+
+func (u UserService) GetPendingEvents(ctx context.Context, userId string, restaurantId string) (_ []dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetPendingEvents")
+	defer func() { endSpan(span, err) }()
+
+	if err = u.requireManager(ctx, restaurantId, userId); err != nil {
+		return nil, err
+	}
+	reservations, err := u.storage.GetPendingEventReservations(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.ReservationDTO, 0, len(reservations))
+	for _, r := range reservations {
+		reservation, err := u.reservationWithTables(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, reservation)
+	}
+	return result, nil
+}
+
+// DecideEvent подтверждает или отклоняет бронь зоны или выкуп, ожидающие решения менеджера.
+// Если правила ресторана требуют депозит, подтвержденная бронь ждет его оплаты.
+func (u UserService) DecideEvent(ctx context.Context, userId string, reservationId string, approve bool) (_ dto.ReservationDTO, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.DecideEvent")
+	defer func() { endSpan(span, err) }()
+
+	reservation, err := u.storage.GetReservationForId(ctx, reservationId)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if reservation == nil {
+		return dto.ReservationDTO{}, domain.ErrReservationNotFound
+	}
+	if err = u.requireManager(ctx, reservation.RestaurantID, userId); err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if !reservation.PendingApproval() {
+		return dto.ReservationDTO{}, domain.ErrNotPendingApproval
+	}
+
+	before := *reservation
+	status, action := domain.ReservationConfirmed, domain.AuditApprove
+	if !approve {
+		status, action = domain.ReservationCanceled, domain.AuditReject
+	}
+	// Платеж регистрируется до смены статуса: если провайдер недоступен,
+	// бронь остается в ожидании и менеджер может повторить решение.
+	var payment *domain.Payment
+	if approve {
+		payment, err = u.requestEventDeposit(ctx, reservation)
+		if err != nil {
+			return dto.ReservationDTO{}, err
+		}
+		if payment != nil {
+			status = domain.ReservationPendingPayment
+		}
+	}
+	ok, err := u.storage.UpdateReservationStatus(ctx, reservation.ID, domain.ReservationWait, status)
+	if err == nil && !ok {
+		err = domain.ErrNotPendingApproval
+	}
+	if err != nil {
+		if payment != nil {
+			if _, cancelErr := u.storage.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentCanceled); cancelErr != nil {
+				u.logger.Error("Failed to cancel deposit of undecided event", "payment_id", payment.ID, "error", cancelErr)
+			}
+		}
+		return dto.ReservationDTO{}, err
+	}
+	reservation.Status = status
+	reservation.Version++
+	u.auditReservation(ctx, userId, action, &before, reservation)
+	if !approve {
+		u.metrics.ReservationCanceled(reservation.RestaurantID)
+		u.offerFreedSlot(ctx, reservation.RestaurantID, reservation.StartTime, reservation.EndTime)
+	}
+	u.logger.Info("Event booking decided", "reservation_id", reservation.ID, "approved", approve, "user_id", userId)
+	result, err := u.reservationWithTables(ctx, reservation)
+	if err != nil {
+		return dto.ReservationDTO{}, err
+	}
+	if payment != nil {
+		result.Payment = fromPaymentDomain(payment)
+	}
+	return result, nil
+}
+
+// requestEventDeposit запрашивает депозит за подтверждаемую бронь зоны или выкуп.
+// Возвращает nil, если правила ресторана депозит не требуют.
+func (u UserService) requestEventDeposit(ctx context.Context, reservation *domain.Reservation) (*domain.Payment, error) {
+	loc, err := u.restaurantLocation(ctx, reservation.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := u.bookingPolicy(ctx, reservation.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	deposit := policy.Deposit(*reservation, loc)
+	if deposit == 0 {
+		return nil, nil
+	}
+	return u.requestDeposit(ctx, reservation, deposit, policy)
+}
+
+// ExpireEventApprovals отменяет брони зон и выкупы, которые менеджер не подтвердил в срок,
+// и предлагает их столики листу ожидания.
+func (u UserService) ExpireEventApprovals(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ExpireEventApprovals")
+	defer func() { endSpan(span, err) }()
+
+	reservations, err := u.storage.GetExpiredApprovals(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		ok, err := u.storage.UpdateReservationStatus(ctx, reservation.ID, domain.ReservationWait, domain.ReservationCanceled)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue // Менеджер успел принять решение
+		}
+		before := *reservation
+		reservation.Status = domain.ReservationCanceled
+		reservation.Version++
+		u.auditReservation(ctx, domain.AuditActorSystem, domain.AuditExpire, &before, reservation)
+		u.metrics.ReservationCanceled(reservation.RestaurantID)
+		u.logger.Info("Event approval expired", "reservation_id", reservation.ID, "restaurant_id", reservation.RestaurantID)
+		u.offerFreedSlot(ctx, reservation.RestaurantID, reservation.StartTime, reservation.EndTime)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/dto"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// bookBuyout выкупает ресторан testRestaurant от имени гостя на три часа с начала start.
+func bookBuyout(service UserService, start time.Time) (dto.ReservationDTO, error) {
+	return service.BookEvent(context.Background(), dto.EventBookingDTO{
+		UserID:       testGuest,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(3 * time.Hour),
+		Capacity:     6,
+	})
+}
+
+func TestBookEventSetsApprovalDeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		startIn  time.Duration
+		hold     time.Duration
+		deadline func(now, start time.Time) time.Time
+	}{
+		{
+			name:     "default hold",
+			startIn:  7 * 24 * time.Hour,
+			deadline: func(now, _ time.Time) time.Time { return now.Add(domain.DefaultApprovalHold) },
+		},
+		{
+			name:     "restaurant hold",
+			startIn:  7 * 24 * time.Hour,
+			hold:     2 * time.Hour,
+			deadline: func(now, _ time.Time) time.Time { return now.Add(2 * time.Hour) },
+		},
+		{
+			name:     "hold ends at the start",
+			startIn:  3 * time.Hour,
+			deadline: func(_, start time.Time) time.Time { return start },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, _ := newTestService(t)
+			storage.addTable("t1", 4)
+			storage.addTable("t2", 4)
+			policy := domain.DefaultBookingPolicy(testRestaurant)
+			policy.ApprovalHold = tt.hold
+			storage.policies[testRestaurant] = policy
+			start := time.Now().Add(tt.startIn).Truncate(time.Hour)
+
+			now := time.Now()
+			created, err := bookBuyout(service, start)
+			if err != nil {
+				t.Fatalf("BookEvent: %v", err)
+			}
+			if created.Status != domain.ReservationWait || created.ApprovalExpiresAt == nil {
+				t.Fatalf("status %s, deadline %v; want pending with deadline", created.Status, created.ApprovalExpiresAt)
+			}
+			want := tt.deadline(now, start)
+			if d := created.ApprovalExpiresAt.Sub(want); d < 0 || d > time.Second {
+				t.Errorf("deadline %s, want %s", created.ApprovalExpiresAt, want)
+			}
+		})
+	}
+}
+
+func TestManagerEventHasNoApprovalDeadline(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	created, err := service.BookEvent(context.Background(), dto.EventBookingDTO{
+		UserID:       testManager,
+		RestaurantID: testRestaurant,
+		StartTime:    start,
+		EndTime:      start.Add(3 * time.Hour),
+		Capacity:     4,
+	})
+	if err != nil {
+		t.Fatalf("BookEvent: %v", err)
+	}
+	if created.Status != domain.ReservationConfirmed || created.ApprovalExpiresAt != nil {
+		t.Errorf("status %s, deadline %v; want confirmed without deadline", created.Status, created.ApprovalExpiresAt)
+	}
+}
+
+func TestExpireEventApprovalsReleasesTables(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	storage.addTable("t2", 4)
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	expired, err := bookBuyout(service, start)
+	if err != nil {
+		t.Fatalf("BookEvent: %v", err)
+	}
+	pending, err := bookBuyout(service, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("BookEvent: %v", err)
+	}
+	stored := storage.reservations[expired.ID]
+	stored.ApprovalExpiresAt = time.Now().Add(-time.Minute)
+	storage.reservations[expired.ID] = stored
+
+	if err := service.ExpireEventApprovals(context.Background()); err != nil {
+		t.Fatalf("ExpireEventApprovals: %v", err)
+	}
+
+	if got := storage.reservation(t, expired.ID); got.Status != domain.ReservationCanceled {
+		t.Errorf("expired buyout status %s, want canceled", got.Status)
+	}
+	if got := storage.reservation(t, pending.ID); got.Status != domain.ReservationWait {
+		t.Errorf("buyout within deadline status %s, want wait", got.Status)
+	}
+	entries := storage.auditEntries(expired.ID)
+	if last := entries[len(entries)-1]; last.Action != domain.AuditExpire || last.ActorID != domain.AuditActorSystem {
+		t.Errorf("last audit entry %s by %s, want expire by system", last.Action, last.ActorID)
+	}
+	if _, err := bookBuyout(service, start); err != nil {
+		t.Errorf("tables still held after approval expired: %v", err)
+	}
+}
+
+func TestExpireEventApprovalsSkipsDecidedEvents(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	storage.addTable("t2", 4)
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	created, err := bookBuyout(service, start)
+	if err != nil {
+		t.Fatalf("BookEvent: %v", err)
+	}
+	if _, err = service.DecideEvent(context.Background(), testManager, created.ID, true); err != nil {
+		t.Fatalf("DecideEvent: %v", err)
+	}
+	stored := storage.reservations[created.ID]
+	stored.ApprovalExpiresAt = time.Now().Add(-time.Minute)
+	storage.reservations[created.ID] = stored
+
+	if err := service.ExpireEventApprovals(context.Background()); err != nil {
+		t.Fatalf("ExpireEventApprovals: %v", err)
+	}
+	if got := storage.reservation(t, created.ID); got.Status != domain.ReservationConfirmed {
+		t.Errorf("approved buyout status %s, want confirmed", got.Status)
+	}
+}
+
+func TestPendingEventsCountTowardActiveBookings(t *testing.T) {
+	service, storage, _ := newTestService(t)
+	storage.addTable("t1", 4)
+	storage.addTable("t2", 4)
+	policy := domain.DefaultBookingPolicy(testRestaurant)
+	policy.MaxActiveBookings = 2
+	storage.policies[testRestaurant] = policy
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	for day := 0; day < 2; day++ {
+		if _, err := bookBuyout(service, start.AddDate(0, 0, day)); err != nil {
+			t.Fatalf("BookEvent day %d: %v", day, err)
+		}
+	}
+	_, err := bookBuyout(service, start.AddDate(0, 0, 2))
+	if !errors.Is(err, domain.ErrTooManyReservations) {
+		t.Fatalf("err = %v, want ErrTooManyReservations", err)
+	}
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"booking_system/internal/domain"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -12,6 +15,31 @@ type Config struct {
 	LogLevel         string
 	NameServiceKafka string
 	TokenBot         string
+	KafkaTopic       string
+	ShutdownTimeout  string
+	ServiceName      string
+	TracingExporter  string
+	OtlpEndpoint     string
+	TracingRatio     string
+	RequestTimeout   string
+	RouteTimeouts    string
+	WaitlistOfferTTL string
+	WaitlistSweep    string
+	PaymentProvider  string
+	YooKassaShopID   string
+	YooKassaSecret   string
+	PaymentReturnURL string
+	PaymentCurrency  string
+	PaymentSweep     string
+	ApprovalSweep    string
+	IdempotencySweep string
+	RateLimitBackend string
+	RateLimitDefault string
+	RateLimits       string
+	RateLimitSweep   string
+	TrustedProxies   string
+	Retention        string
+	RetentionSweep   string
 }
 
 func NewConfig() *Config {
@@ -22,6 +50,31 @@ func NewConfig() *Config {
 		LogLevel:         getEnv("LOG_LEVEL", "debug"),
 		NameServiceKafka: getEnv("NAME_SERVICE_KAFKA", ""),
 		TokenBot:         getEnv("TOKEN_BOT", "7617376673:AAHLqRlZN21_FeIxduDLDvV0-Z6XQnCmeBw"),
+		KafkaTopic:       getEnv("KAFKA_TOPIC", "booking_events"),
+		ShutdownTimeout:  getEnv("SHUTDOWN_TIMEOUT", "15s"),
+		ServiceName:      getEnv("SERVICE_NAME", "booking_system"),
+		TracingExporter:  getEnv("TRACING_EXPORTER", "none"),
+		OtlpEndpoint:     getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4318"),
+		TracingRatio:     getEnv("TRACING_SAMPLE_RATIO", "1"),
+		RequestTimeout:   getEnv("REQUEST_TIMEOUT", "5s"),
+		RouteTimeouts:    getEnv("ROUTE_TIMEOUTS", ""),
+		WaitlistOfferTTL: getEnv("WAITLIST_OFFER_TTL", "15m"),
+		WaitlistSweep:    getEnv("WAITLIST_SWEEP_INTERVAL", "1m"),
+		PaymentProvider:  getEnv("PAYMENT_PROVIDER", ""),
+		YooKassaShopID:   getEnv("YOOKASSA_SHOP_ID", ""),
+		YooKassaSecret:   getEnv("YOOKASSA_SECRET_KEY", ""),
+		PaymentReturnURL: getEnv("PAYMENT_RETURN_URL", "http://localhost:8080/payments/return"),
+		PaymentCurrency:  getEnv("PAYMENT_CURRENCY", "RUB"),
+		PaymentSweep:     getEnv("PAYMENT_SWEEP_INTERVAL", "1m"),
+		ApprovalSweep:    getEnv("APPROVAL_SWEEP_INTERVAL", "5m"),
+		IdempotencySweep: getEnv("IDEMPOTENCY_SWEEP_INTERVAL", "1h"),
+		RateLimitBackend: getEnv("RATE_LIMIT_BACKEND", "memory"),
+		RateLimitDefault: getEnv("RATE_LIMIT_DEFAULT", "30/1m"),
+		RateLimits:       getEnv("RATE_LIMITS", ""),
+		RateLimitSweep:   getEnv("RATE_LIMIT_SWEEP_INTERVAL", "10m"),
+		TrustedProxies:   getEnv("TRUSTED_PROXIES", ""),
+		Retention:        getEnv("RESERVATION_RETENTION", "8760h"),
+		RetentionSweep:   getEnv("RETENTION_SWEEP_INTERVAL", "24h"),
 	}
 }
 
@@ -39,3 +92,179 @@ func (c *Config) GetHttpPort() int {
 	}
 	return port
 }
+
+func (c *Config) GetKafkaBrokers() []string {
+	return strings.Split(c.KafkaServer, ",")
+}
+
+func (c *Config) GetShutdownTimeout() time.Duration {
+	timeout, err := time.ParseDuration(c.ShutdownTimeout)
+	if err != nil {
+		panic(err)
+	}
+	return timeout
+}
+
+func (c *Config) GetTracingRatio() float64 {
+	ratio, err := strconv.ParseFloat(c.TracingRatio, 64)
+	if err != nil {
+		panic(err)
+	}
+	return ratio
+}
+
+func (c *Config) GetRequestTimeout() time.Duration {
+	timeout, err := time.ParseDuration(c.RequestTimeout)
+	if err != nil {
+		panic(err)
+	}
+	return timeout
+}
+
+func (c *Config) GetWaitlistOfferTTL() time.Duration {
+	ttl, err := time.ParseDuration(c.WaitlistOfferTTL)
+	if err != nil {
+		panic(err)
+	}
+	return ttl
+}
+
+func (c *Config) GetWaitlistSweepInterval() time.Duration {
+	interval, err := time.ParseDuration(c.WaitlistSweep)
+	if err != nil {
+		panic(err)
+	}
+	return interval
+}
+
+func (c *Config) GetPaymentSweepInterval() time.Duration {
+	interval, err := time.ParseDuration(c.PaymentSweep)
+	if err != nil {
+		panic(err)
+	}
+	return interval
+}
+
+func (c *Config) GetApprovalSweepInterval() time.Duration {
+	interval, err := time.ParseDuration(c.ApprovalSweep)
+	if err != nil {
+		panic(err)
+	}
+	return interval
+}
+
+func (c *Config) GetIdempotencySweepInterval() time.Duration {
+	interval, err := time.ParseDuration(c.IdempotencySweep)
+	if err != nil {
+		panic(err)
+	}
+	return interval
+}
+
+// GetRouteTimeouts разбирает ROUTE_TIMEOUTS вида
+// "POST /api/v1/:restaurantId/booking=10s,GET /api/v1/booking/me=2s".
+func (c *Config) GetRouteTimeouts() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	if c.RouteTimeouts == "" {
+		return timeouts
+	}
+	for _, entry := range strings.Split(c.RouteTimeouts, ",") {
+		route, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			panic("invalid ROUTE_TIMEOUTS entry: " + entry)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			panic(err)
+		}
+		timeouts[strings.TrimSpace(route)] = timeout
+	}
+	return timeouts
+}
+
+func (c *Config) GetRateLimitSweepInterval() time.Duration {
+	interval, err := time.ParseDuration(c.RateLimitSweep)
+	if err != nil {
+		panic(err)
+	}
+	return interval
+}
+
+// GetReservationRetention возвращает срок, после которого закончившиеся брони архивируются
+// с удалением контактов гостя. Пустое или нулевое значение отключает архивацию.
+func (c *Config) GetReservationRetention() time.Duration {
+	if c.Retention == "" {
+		return 0
+	}
+	retention, err := time.ParseDuration(c.Retention)
+	if err != nil {
+		panic(err)
+	}
+	return retention
+}
+
+func (c *Config) GetRetentionSweepInterval() time.Duration {
+	interval, err := time.ParseDuration(c.RetentionSweep)
+	if err != nil {
+		panic(err)
+	}
+	return interval
+}
+
+// GetRateLimitDefault разбирает RATE_LIMIT_DEFAULT вида "30/1m": 30 запросов в минуту.
+// Пустое значение отключает ограничение для роутов без своего лимита.
+func (c *Config) GetRateLimitDefault() domain.RateLimit {
+	if c.RateLimitDefault == "" {
+		return domain.RateLimit{}
+	}
+	return parseRateLimit(c.RateLimitDefault)
+}
+
+// GetRouteRateLimits разбирает RATE_LIMITS вида
+// "POST /api/v1/:restaurantId/booking=5/1m,GET /api/v1/auth/telegram=10/1m".
+func (c *Config) GetRouteRateLimits() map[string]domain.RateLimit {
+	limits := make(map[string]domain.RateLimit)
+	if c.RateLimits == "" {
+		return limits
+	}
+	for _, entry := range strings.Split(c.RateLimits, ",") {
+		route, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			panic("invalid RATE_LIMITS entry: " + entry)
+		}
+		limits[strings.TrimSpace(route)] = parseRateLimit(value)
+	}
+	return limits
+}
+
+// GetTrustedProxies разбирает TRUSTED_PROXIES — адреса и подсети прокси через запятую,
+// чьему X-Forwarded-For верит сервер. Пустое значение — заголовку не верим, адрес клиента
+// берется из соединения.
+func (c *Config) GetTrustedProxies() []string {
+	if c.TrustedProxies == "" {
+		return nil
+	}
+	var proxies []string
+	for _, proxy := range strings.Split(c.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+func parseRateLimit(value string) domain.RateLimit {
+	burst, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		panic("invalid rate limit: " + value)
+	}
+	n, err := strconv.Atoi(burst)
+	if err != nil {
+		panic(err)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil {
+		panic(err)
+	}
+	return domain.RateLimit{Burst: n, Period: d}
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Сущности, изменения которых попадают в журнал аудита.
+const (
+	AuditEntityReservation = "reservation"
+	AuditEntityTable       = "table"
+	AuditEntityRestaurant  = "restaurant"
+)
+
+// Действия журнала аудита.
+const (
+	AuditCreate           = "create"
+	AuditUpdate           = "update"
+	AuditCancel           = "cancel"
+	AuditReschedule       = "reschedule"
+	AuditApprove          = "approve"
+	AuditReject           = "reject"
+	AuditSeat             = "seat"
+	AuditLeave            = "leave"
+	AuditNoShow           = "no_show"
+	AuditPay              = "pay"
+	AuditExpire           = "expire"
+	AuditBlock            = "block"
+	AuditUnblock          = "unblock"
+	AuditDelete           = "delete"
+	AuditUpdatePolicy     = "update_policy"
+	AuditUpdateHours      = "update_hours"
+	AuditSaveSpecialDay   = "save_special_day"
+	AuditDeleteSpecialDay = "delete_special_day"
+	AuditCreateClosure    = "create_closure"
+	AuditDeleteClosure    = "delete_closure"
+	AuditSaveZone         = "save_zone"
+	AuditDeleteZone       = "delete_zone"
+)
+
+// AuditActorSystem — автор изменений, которые сделали фоновые задачи или платежный провайдер.
+const AuditActorSystem = "system"
+
+// Сколько записей журнала аудита возвращается за один запрос.
+const (
+	AuditDefaultLimit = 100
+	AuditMaxLimit     = 500
+)
+
+// AuditEntry — запись журнала аудита. Записи только добавляются и никогда не меняются.
+type AuditEntry struct {
+	ID           string
+	RestaurantID string
+	EntityType   string
+	EntityID     string
+	Action       string
+	ActorID      string // Пользователь, сделавший изменение, или AuditActorSystem
+	RequestID    string // ID HTTP-запроса, пусто для фоновых задач
+	Changes      map[string]AuditChange
+	CreatedAt    time.Time
+}
+
+// AuditChange — значение поля до и после изменения в JSON, null — поля не было.
+type AuditChange struct {
+	From json.RawMessage
+	To   json.RawMessage
+}
+
+// AuditRedacted записывается в журнал вместо персональных данных гостя.
+var AuditRedacted = json.RawMessage(`"redacted"`)
+
+// auditSensitiveFields — поля снимков с персональными данными гостя. Журнал неизменяемый
+// и хранится дольше самих броней, поэтому в него попадает только факт изменения этих полей.
+var auditSensitiveFields = []string{"contacts"}
+
+// AuditFilter — условия выборки журнала аудита ресторана. Пустые поля не ограничивают выборку.
+type AuditFilter struct {
+	RestaurantID string
+	EntityType   string
+	EntityID     string
+	ActorID      string
+	From         time.Time
+	To           time.Time
+	Limit        int
+	Offset       int
+}
+
+// AuditDiff сравнивает два снимка сущности по полям их JSON-представления и возвращает
+// изменившиеся поля. before == nil означает, что сущность создана, after == nil — удалена.
+func AuditDiff(before, after any) (map[string]AuditChange, error) {
+	from, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[string]AuditChange)
+	for field, value := range from {
+		if !bytes.Equal(value, to[field]) {
+			changes[field] = AuditChange{From: value, To: to[field]}
+		}
+	}
+	for field, value := range to {
+		if _, ok := from[field]; !ok {
+			changes[field] = AuditChange{To: value}
+		}
+	}
+	return changes, nil
+}
+
+// RedactAuditChanges заменяет значения полей с персональными данными на AuditRedacted.
+// Отсутствующее значение остается null, чтобы было видно, что поле появилось или исчезло.
+func RedactAuditChanges(changes map[string]AuditChange) {
+	for _, field := range auditSensitiveFields {
+		change, ok := changes[field]
+		if !ok {
+			continue
+		}
+		changes[field] = AuditChange{From: redactAuditValue(change.From), To: redactAuditValue(change.To)}
+	}
+}
+
+func redactAuditValue(value json.RawMessage) json.RawMessage {
+	if value == nil || bytes.Equal(value, []byte("null")) {
+		return value
+	}
+	return AuditRedacted
+}
+
+func auditFields(snapshot any) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if snapshot == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+type requestIDKey struct{}
+
+// WithRequestID сохраняет ID HTTP-запроса в контексте, чтобы сценарии могли записать его в журнал аудита.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID возвращает ID HTTP-запроса из контекста или пустую строку.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAuditDiffRedactsContacts(t *testing.T) {
+	type contacts struct {
+		Name  string `json:"name"`
+		Phone string `json:"phone"`
+	}
+	type snapshot struct {
+		Status   string    `json:"status"`
+		Contacts *contacts `json:"contacts"`
+	}
+	anna := &contacts{Name: "Anna", Phone: "+79990000001"}
+	boris := &contacts{Name: "Boris", Phone: "+79990000002"}
+
+	tests := []struct {
+		name          string
+		before, after any
+		wantFrom      string
+		wantTo        string
+	}{
+		{name: "created", before: nil, after: snapshot{Contacts: anna}, wantFrom: "", wantTo: `"redacted"`},
+		{name: "changed", before: snapshot{Contacts: anna}, after: snapshot{Contacts: boris}, wantFrom: `"redacted"`, wantTo: `"redacted"`},
+		{name: "cleared", before: snapshot{Contacts: anna}, after: snapshot{}, wantFrom: `"redacted"`, wantTo: "null"},
+		{name: "deleted", before: snapshot{Contacts: anna}, after: nil, wantFrom: `"redacted"`, wantTo: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := AuditDiff(tt.before, tt.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			RedactAuditChanges(changes)
+
+			change, ok := changes["contacts"]
+			if !ok {
+				t.Fatalf("contacts change is not recorded: %v", changes)
+			}
+			if string(change.From) != tt.wantFrom || string(change.To) != tt.wantTo {
+				t.Errorf("contacts change = %s -> %s, want %s -> %s", change.From, change.To, tt.wantFrom, tt.wantTo)
+			}
+			data, err := json.Marshal(changes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, pii := range []string{"Anna", "Boris", "+7999"} {
+				if strings.Contains(string(data), pii) {
+					t.Errorf("audit changes leak %q: %s", pii, data)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactAuditChangesKeepsOtherFields(t *testing.T) {
+	changes, err := AuditDiff(map[string]any{"status": "wait"}, map[string]any{"status": "sucess"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	RedactAuditChanges(changes)
+	if got := string(changes["status"].To); got != `"sucess"` {
+		t.Errorf("status change = %s, want \"sucess\"", got)
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+)
+
+// TableBlock снимает столик с обслуживания на интервал [StartTime, EndTime):
+// частное мероприятие, ремонт или придержанный для гостя стол.
+type TableBlock struct {
+	ID           string
+	TableID      string
+	RestaurantID string
+	StartTime    time.Time
+	EndTime      time.Time
+	Reason       string
+	CreatedBy    string // ID пользователя, заблокировавшего столик
+	CreatedAt    time.Time
+}
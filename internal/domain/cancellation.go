@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+)
+
+// Cancel отменяет бронь по просьбе гостя в момент now. Начавшуюся бронь отменить
+// нельзя, а отмена позже CancellationCutoff до начала отмечается как поздняя.
+func (rv *Reservation) Cancel(policy BookingPolicy, now time.Time) error {
+	if rv.Status == ReservationCanceled {
+		return ErrReservationCanceled
+	}
+	if !rv.SeatedAt.IsZero() {
+		return ErrAlreadySeated
+	}
+	if !now.Before(rv.StartTime) {
+		return ErrCancellationClosed
+	}
+	rv.Status = ReservationCanceled
+	rv.CanceledAt = now
+	rv.LateCancel = policy.CancellationCutoff > 0 && now.After(rv.StartTime.Add(-policy.CancellationCutoff))
+	return nil
+}
+
+// MarkNoShow отмечает, что гость не пришел. Отметить неявку можно только после начала брони.
+func (rv *Reservation) MarkNoShow(now time.Time) error {
+	if rv.Status == ReservationCanceled {
+		return ErrReservationCanceled
+	}
+	if !rv.SeatedAt.IsZero() {
+		return ErrAlreadySeated
+	}
+	if now.Before(rv.StartTime) {
+		return ErrNoShowTooEarly
+	}
+	rv.Status = ReservationCanceled
+	rv.CanceledAt = now
+	rv.NoShow = true
+	return nil
+}
+
+// GuestReliability — история посещений гостя по всем ресторанам.
+type GuestReliability struct {
+	UserID      string
+	Visits      int // Брони, по которым гость пришел
+	NoShows     int
+	LateCancels int
+}
+
+// Score оценивает надежность гостя от 0 до 100: доля броней, по которым гость пришел,
+// где поздняя отмена засчитывается наполовину. Гость без истории получает 100.
+func (g GuestReliability) Score() int {
+	total := g.Visits + g.NoShows + g.LateCancels
+	if total == 0 {
+		return 100
+	}
+	return (100*g.Visits + 50*g.LateCancels) / total
+}
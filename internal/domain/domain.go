@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"time"
 )
 
@@ -15,10 +14,16 @@ type User struct {
 
 // Restaurant представляет ресторан.
 type Restaurant struct {
-	ID      string // Уникальный идентификатор ресторана
-	Name    string // Название ресторана
-	Address string // Адрес ресторана
-	Phone   string // Телефон ресторана
+	ID       string // Уникальный идентификатор ресторана
+	Name     string // Название ресторана
+	Address  string // Адрес ресторана
+	Phone    string // Телефон ресторана
+	Timezone string // Часовой пояс IANA, в котором ресторан работает и принимает брони
+}
+
+// Location возвращает часовой пояс ресторана. Пустой пояс трактуется как UTC.
+func (r Restaurant) Location() (*time.Location, error) {
+	return time.LoadLocation(r.Timezone)
 }
 
 // Table представляет столик в ресторане.
@@ -35,13 +40,76 @@ type Table struct {
 // Reservation представляет бронь столика.
 type Reservation struct {
 	ID           string
-	UserID       string
+	UserID       string // Пусто для гостей, посаженных без брони
 	RestaurantID string
 	StartTime    time.Time
 	EndTime      time.Time
 	Status       string // Статус брони (отменена, подтверждена, в ожидании подтверждения)
 	Capacity     int
 	Contacts     Contacts
+	Source       string    // Откуда пришла бронь: online или walk_in
+	SeatedAt     time.Time // Когда гостей посадили, нулевое значение — еще не пришли
+	LeftAt       time.Time // Когда гости ушли и освободили столик
+	SeriesID     string    // Серия повторяющихся броней, пусто для разовой брони
+	Scope        string    // Что занимает бронь: отдельные столики, зону или весь ресторан
+	ZoneID       string    // Забронированная зона при Scope == ReservationScopeZone
+	CanceledAt   time.Time // Когда бронь отменили или отметили неявку
+	LateCancel   bool      // Гость отменил бронь позже CancellationCutoff до начала
+	NoShow       bool      // Гость не пришел, бронь отменена персоналом
+	Version      int64     // Растет при каждом изменении брони, изменение со старой версией отклоняется
+	// До какого момента менеджер должен подтвердить бронь зоны или выкуп, иначе она отменяется
+	ApprovalExpiresAt time.Time
+}
+
+// Статусы брони. Написание "sucess" закреплено в схеме базы.
+const (
+	ReservationWait      = "wait"
+	ReservationConfirmed = "sucess"
+	ReservationCanceled  = "canceled"
+	// ReservationPendingPayment — столики придержаны, пока гость не внесет депозит
+	ReservationPendingPayment = "pending_payment"
+)
+
+// Источники брони.
+const (
+	ReservationSourceOnline = "online"
+	ReservationSourceWalkIn = "walk_in"
+)
+
+// Seat отмечает, что гости пришли и сели за столик. Если они пришли раньше
+// забронированного времени, бронь начинается с момента посадки.
+func (rv *Reservation) Seat(now time.Time) error {
+	if rv.Status == ReservationCanceled {
+		return ErrReservationCanceled
+	}
+	if rv.Status == ReservationPendingPayment {
+		return ErrDepositNotPaid
+	}
+	if !rv.SeatedAt.IsZero() {
+		return ErrAlreadySeated
+	}
+	rv.SeatedAt = now
+	if now.Before(rv.StartTime) {
+		rv.StartTime = now
+	}
+	return nil
+}
+
+// Leave отмечает, что гости ушли. Бронь заканчивается в момент ухода,
+// поэтому столик сразу становится свободным.
+func (rv *Reservation) Leave(now time.Time) error {
+	if rv.SeatedAt.IsZero() {
+		return ErrNotSeated
+	}
+	if !rv.LeftAt.IsZero() {
+		return ErrAlreadyLeft
+	}
+	rv.LeftAt = now
+	rv.EndTime = now
+	if !rv.EndTime.After(rv.StartTime) {
+		rv.EndTime = rv.StartTime.Add(time.Minute)
+	}
+	return nil
 }
 
 type Contacts struct {
@@ -49,20 +117,59 @@ type Contacts struct {
 	Phone string
 }
 
-func (rv Reservation) CheckDate() (bool, error) {
-	now := time.Now().Local()
-
+// CheckDate проверяет время брони по правилам ресторана относительно момента now.
+// Сетка слотов строится от полуночи в часовом поясе ресторана loc.
+func (rv Reservation) CheckDate(policy BookingPolicy, now time.Time, loc *time.Location) (bool, error) {
 	if rv.StartTime.Before(now) {
-		return false, errors.New("StartTime должна быть позже или равна текущему времени")
+		return false, ErrStartTimeInPast
+	}
+	if !rv.EndTime.After(rv.StartTime) {
+		return false, ErrInvalidDate
+	}
+	if policy.LeadTime > 0 && rv.StartTime.Before(now.Add(policy.LeadTime)) {
+		return false, ErrLeadTime.Withf("бронировать нужно не позднее чем за %s до начала", policy.LeadTime)
 	}
+	if policy.BookingHorizon > 0 && rv.StartTime.After(now.Add(policy.BookingHorizon)) {
+		return false, ErrBeyondHorizon.Withf("бронировать можно не более чем на %s вперед", policy.BookingHorizon)
+	}
+
 	duration := rv.EndTime.Sub(rv.StartTime)
-	if duration > 2*time.Hour {
-		return false, errors.New("разница между StartTime и EndTime не должна превышать 2 часа")
+	if policy.MaxDuration > 0 && duration > policy.MaxDuration {
+		return false, ErrDurationTooLong.Withf("разница между StartTime и EndTime не должна превышать %s", policy.MaxDuration)
+	}
+	if policy.MinDuration > 0 && duration < policy.MinDuration {
+		return false, ErrDurationTooShort.Withf("разница между StartTime и EndTime должна быть не меньше %s", policy.MinDuration)
+	}
+	if policy.SlotGranularity > 0 {
+		if !alignedTo(rv.StartTime.In(loc), policy.SlotGranularity) || !alignedTo(rv.EndTime.In(loc), policy.SlotGranularity) {
+			return false, ErrSlotMisaligned.Withf("StartTime и EndTime должны быть кратны %s", policy.SlotGranularity)
+		}
 	}
 
 	return true, nil
 }
 
+// alignedTo проверяет, что t попадает на сетку с шагом step, отсчитываемую от полуночи
+// по настенным часам. В день перехода на летнее время сетка не сдвигается.
+func alignedTo(t time.Time, step time.Duration) bool {
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	return clock%step == 0 && t.Nanosecond() == 0
+}
+
+// CheckPolicy проверяет бронь целиком: время, число столиков и гостей.
+func (rv Reservation) CheckPolicy(policy BookingPolicy, tablesCount int, now time.Time, loc *time.Location) error {
+	if _, err := rv.CheckDate(policy, now, loc); err != nil {
+		return err
+	}
+	if policy.MaxTablesPerBooking > 0 && tablesCount > policy.MaxTablesPerBooking {
+		return ErrTooManyTables.Withf("в одной брони может быть не более %d столиков", policy.MaxTablesPerBooking)
+	}
+	if policy.MaxPartySize > 0 && rv.Capacity > policy.MaxPartySize {
+		return ErrPartyTooLarge.Withf("в одной брони может быть не более %d гостей", policy.MaxPartySize)
+	}
+	return nil
+}
+
 // ReservationTable представляет связь между бронированием и столиком.
 type ReservationTable struct {
 	ID            string
@@ -73,4 +180,5 @@ type ReservationTable struct {
 type TableAvailability struct {
 	Table
 	IsAvailable bool `json:"is_available"`
+	IsOccupied  bool `json:"is_occupied"` // За столиком сейчас сидят гости
 }
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func berlin(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	return loc
+}
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// В Берлине 2026-03-29 часы переводятся с 02:00 CET на 03:00 CEST,
+// а 2026-10-25 — с 03:00 CEST обратно на 02:00 CET.
+func TestCheckDateAcrossDST(t *testing.T) {
+	loc := berlin(t)
+	now := utc("2026-03-01T00:00:00Z")
+	policy := BookingPolicy{MaxDuration: 2 * time.Hour, SlotGranularity: 30 * time.Minute}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		wantErr    error
+	}{
+		{
+			name:  "spans spring forward",
+			start: utc("2026-03-29T00:30:00Z"), // 01:30 CET
+			end:   utc("2026-03-29T01:30:00Z"), // 03:30 CEST
+		},
+		{
+			name:  "two real hours across spring forward are within max duration",
+			start: utc("2026-03-29T00:00:00Z"), // 01:00 CET
+			end:   utc("2026-03-29T02:00:00Z"), // 04:00 CEST, на часах три часа
+		},
+		{
+			name:    "duration counts real time, not wall clock",
+			start:   utc("2026-03-28T23:30:00Z"), // 00:30 CET
+			end:     utc("2026-03-29T02:00:00Z"), // 04:00 CEST
+			wantErr: ErrDurationTooLong,
+		},
+		{
+			name:  "slot in skipped hour is normalized past the gap",
+			start: time.Date(2026, 3, 29, 2, 30, 0, 0, loc), // 02:30 не существует, это 03:30 CEST
+			end:   time.Date(2026, 3, 29, 4, 30, 0, 0, loc),
+		},
+		{
+			name:  "first half of repeated hour",
+			start: utc("2026-10-25T00:00:00Z"), // 02:00 CEST
+			end:   utc("2026-10-25T00:30:00Z"), // 02:30 CEST
+		},
+		{
+			name:  "spans fall back",
+			start: utc("2026-10-25T00:30:00Z"), // 02:30 CEST
+			end:   utc("2026-10-25T01:30:00Z"), // 02:30 CET
+		},
+		{
+			name:    "misaligned in repeated hour",
+			start:   utc("2026-10-25T01:15:00Z"), // 02:15 CET
+			end:     utc("2026-10-25T02:00:00Z"),
+			wantErr: ErrSlotMisaligned,
+		},
+		{
+			name:    "three real hours across fall back exceed max duration",
+			start:   utc("2026-10-25T00:00:00Z"), // 02:00 CEST
+			end:     utc("2026-10-25T03:00:00Z"), // 04:00 CET, на часах два часа
+			wantErr: ErrDurationTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rv := Reservation{StartTime: tt.start, EndTime: tt.end}
+			ok, err := rv.CheckDate(policy, now, loc)
+			if tt.wantErr == nil {
+				if err != nil || !ok {
+					t.Fatalf("CheckDate() = %v, %v; want ok", ok, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckDate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSkippedHourNormalization(t *testing.T) {
+	loc := berlin(t)
+	slot := time.Date(2026, 3, 29, 2, 30, 0, 0, loc)
+	if got := slot.Format("15:04 MST"); got != "03:30 CEST" {
+		t.Fatalf("02:30 in the skipped hour = %s, want 03:30 CEST", got)
+	}
+	if !slot.Equal(utc("2026-03-29T01:30:00Z")) {
+		t.Fatalf("02:30 in the skipped hour = %s, want 01:30 UTC", slot.UTC())
+	}
+}
+
+func TestAlignedToUsesLocalWallClock(t *testing.T) {
+	loc := berlin(t)
+	tests := []struct {
+		name string
+		at   time.Time
+		step time.Duration
+		want bool
+	}{
+		{"last slot before spring forward", utc("2026-03-29T00:30:00Z"), 30 * time.Minute, true},
+		{"first hour after spring forward", utc("2026-03-29T01:00:00Z"), time.Hour, true},
+		{"first pass of repeated hour", utc("2026-10-25T00:00:00Z"), time.Hour, true},
+		{"second pass of repeated hour", utc("2026-10-25T01:00:00Z"), time.Hour, true},
+		{"quarter in repeated hour", utc("2026-10-25T01:15:00Z"), 30 * time.Minute, false},
+		{"90 minute grid is counted from local midnight", utc("2026-07-01T01:30:00Z"), 90 * time.Minute, false}, // 03:30 CEST
+		{"90 minute grid on local slot", utc("2026-07-01T02:30:00Z"), 90 * time.Minute, true},                   // 04:30 CEST
+		{"nanoseconds", utc("2026-03-29T01:00:00Z").Add(time.Nanosecond), time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alignedTo(tt.at.In(loc), tt.step); got != tt.want {
+				t.Errorf("alignedTo(%s, %s) = %v, want %v", tt.at.In(loc).Format("15:04 MST"), tt.step, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package domain
+
+import "fmt"
+
+// ErrorKind — категория доменной ошибки, по которой адаптеры выбирают код ответа.
+type ErrorKind string
+
+const (
+	KindNotFound   ErrorKind = "not_found"
+	KindConflict   ErrorKind = "conflict"
+	KindValidation ErrorKind = "validation"
+	KindForbidden  ErrorKind = "forbidden"
+	KindInternal   ErrorKind = "internal"
+	KindRateLimit  ErrorKind = "rate_limit"
+)
+
+// Error — доменная ошибка со стабильным машинно-читаемым кодом.
+// Две ошибки считаются одинаковыми для errors.Is, если совпадают их коды,
+// поэтому уточненное сообщение не мешает сравнению с эталонной ошибкой.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Withf возвращает копию ошибки с уточненным сообщением.
+func (e *Error) Withf(format string, args ...interface{}) *Error {
+	c := *e
+	c.Message = fmt.Sprintf(format, args...)
+	return &c
+}
+
+// Wrap возвращает копию ошибки с причиной err.
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+func NewNotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func NewConflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func NewValidation(code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func NewForbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func NewInternal(code, message string) *Error {
+	return &Error{Kind: KindInternal, Code: code, Message: message}
+}
+
+func NewRateLimit(code, message string) *Error {
+	return &Error{Kind: KindRateLimit, Code: code, Message: message}
+}
+
+var (
+	ErrReservationNotFound   = NewNotFound("reservation_not_found", "reservation not found")
+	ErrTableNotFound         = NewNotFound("table_not_found", "table not found")
+	ErrRestaurantNotFound    = NewNotFound("restaurant_not_found", "restaurant not found")
+	ErrClosureNotFound       = NewNotFound("closure_not_found", "closure not found")
+	ErrSpecialDayNotFound    = NewNotFound("special_day_not_found", "special day not found")
+	ErrTableBlockNotFound    = NewNotFound("table_block_not_found", "table block not found")
+	ErrWaitlistNotFound      = NewNotFound("waitlist_entry_not_found", "waitlist entry not found")
+	ErrSeriesNotFound        = NewNotFound("series_not_found", "reservation series not found")
+	ErrZoneNotFound          = NewNotFound("zone_not_found", "zone not found")
+	ErrPaymentNotFound       = NewNotFound("payment_not_found", "payment not found")
+	ErrGuestNotFound         = NewNotFound("guest_not_found", "guest has no reservations in this restaurant")
+	ErrTableNotAvailable     = NewConflict("table_not_available", "table not available")
+	ErrNoActiveOffer         = NewConflict("no_active_offer", "waitlist entry has no active offer")
+	ErrWaitlistClosed        = NewConflict("waitlist_entry_closed", "waitlist entry is no longer active")
+	ErrReservationCanceled   = NewConflict("reservation_canceled", "reservation is canceled")
+	ErrAlreadySeated         = NewConflict("already_seated", "guests are already seated")
+	ErrNotSeated             = NewConflict("not_seated", "guests are not seated yet")
+	ErrDepositNotPaid        = NewConflict("deposit_not_paid", "reservation deposit is not paid yet")
+	ErrDepositIncrease       = NewConflict("deposit_increase_required", "the change requires a larger deposit, cancel the reservation and book again")
+	ErrCancellationClosed    = NewConflict("cancellation_closed", "reservation has already started and can no longer be canceled")
+	ErrNoShowTooEarly        = NewConflict("no_show_too_early", "reservation has not started yet")
+	ErrIdempotencyKeyReused  = NewConflict("idempotency_key_reused", "idempotency key was already used for a different request")
+	ErrIdempotencyInProgress = NewConflict("idempotency_in_progress", "request with this idempotency key is still in progress")
+	ErrAlreadyLeft           = NewConflict("already_left", "guests have already left")
+	ErrSeriesConflict        = NewConflict("series_conflict", "no reservation of the series could be booked")
+	ErrNotPendingApproval    = NewConflict("not_pending_approval", "reservation is not waiting for approval")
+	ErrReservationModified   = NewConflict("reservation_modified", "reservation was modified by another request, reload it and retry")
+	ErrTableHasReservations  = NewConflict("table_has_reservations", "table has upcoming reservations")
+	ErrInvalidDate           = NewValidation("invalid_date", "invalid date")
+	ErrStartTimeInPast       = NewValidation("start_time_in_past", "StartTime должна быть позже или равна текущему времени")
+	ErrDurationTooLong       = NewValidation("duration_too_long", "reservation is too long")
+	ErrDurationTooShort      = NewValidation("duration_too_short", "reservation is too short")
+	ErrSlotMisaligned        = NewValidation("slot_misaligned", "reservation is not aligned to the slot grid")
+	ErrLeadTime              = NewValidation("lead_time", "reservation starts too soon")
+	ErrBeyondHorizon         = NewValidation("beyond_horizon", "reservation is too far in the future")
+	ErrTooManyTables         = NewValidation("too_many_tables", "too many tables")
+	ErrPartyTooLarge         = NewValidation("party_too_large", "party size exceeds the restaurant limit")
+	ErrCapacityExceeded      = NewValidation("capacity_exceeded", "capacity exceeded")
+	ErrInvalidPolicy         = NewValidation("invalid_policy", "invalid booking policy")
+	ErrRestaurantClosed      = NewValidation("restaurant_closed", "restaurant is closed at the requested time")
+	ErrInvalidRecurrence     = NewValidation("invalid_recurrence", "invalid recurrence rule")
+	ErrInvalidZone           = NewValidation("invalid_zone", "invalid zone")
+	ErrInvalidNotification   = NewValidation("invalid_payment_notification", "invalid payment notification")
+	ErrTooManyReservations   = NewValidation("too_many_active_reservations", "too many active reservations in this restaurant")
+	ErrReservationForbidden  = NewForbidden("reservation_forbidden", "reservation belongs to another user")
+	ErrNotRestaurantManager  = NewForbidden("not_restaurant_manager", "user is not a manager of the restaurant")
+	ErrNotRestaurantStaff    = NewForbidden("not_restaurant_staff", "user is not a staff member of the restaurant")
+	ErrWaitlistForbidden     = NewForbidden("waitlist_forbidden", "waitlist entry belongs to another user")
+	ErrSeriesForbidden       = NewForbidden("series_forbidden", "reservation series belongs to another user")
+	ErrPaymentUnavailable    = NewInternal("payment_unavailable", "payment provider is unavailable")
+	ErrTooManyRequests       = NewRateLimit("too_many_requests", "too many requests, try again later")
+)
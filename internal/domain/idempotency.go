@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+)
+
+// IdempotencyTTL — сколько хранится результат запроса с ключом идемпотентности.
+const IdempotencyTTL = 24 * time.Hour
+
+// IdempotencyLockTimeout — через сколько незавершенный запрос считается брошенным,
+// например после падения сервиса, и его ключ можно занять заново.
+const IdempotencyLockTimeout = time.Minute
+
+// IdempotencyRecord — запрос гостя с ключом идемпотентности и его результат.
+type IdempotencyRecord struct {
+	UserID      string
+	Key         string
+	Fingerprint string // SHA-256 содержимого запроса
+	Response    []byte // Сохраненный результат, nil — запрос еще выполняется
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Replay проверяет, что повтор с ключом записи можно ответить сохраненным результатом:
+// содержимое запроса должно совпадать, а первый запрос — завершиться.
+func (r IdempotencyRecord) Replay(fingerprint string) error {
+	if r.Fingerprint != fingerprint {
+		return ErrIdempotencyKeyReused
+	}
+	if r.Response == nil {
+		return ErrIdempotencyInProgress
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+)
+
+// Статусы платежа за бронь.
+const (
+	PaymentPending  = "pending"  // Ждем оплату, столики придержаны до ExpiresAt
+	PaymentPaid     = "paid"     // Депозит получен, бронь подтверждена
+	PaymentFailed   = "failed"   // Провайдер отклонил платеж или гость отказался платить
+	PaymentExpired  = "expired"  // Срок удержания столиков истек до оплаты
+	PaymentCanceled = "canceled" // Бронь отменена до оплаты
+	PaymentRefunded = "refunded" // Депозит возвращен гостю
+)
+
+// DefaultPaymentHold — сколько держатся столики в ожидании оплаты, если ресторан не задал срок.
+const DefaultPaymentHold = 15 * time.Minute
+
+// Payment — депозит за бронь у платежного провайдера.
+type Payment struct {
+	ID              string
+	ReservationID   string
+	Provider        string
+	ExternalID      string // ID платежа у провайдера
+	Amount          int64  // Сумма в минимальных единицах валюты (копейках)
+	Currency        string
+	Status          string
+	ConfirmationURL string // Страница оплаты для гостя
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+}
+
+// PaymentIntent — платеж, зарегистрированный у провайдера.
+type PaymentIntent struct {
+	ExternalID      string
+	ConfirmationURL string
+}
+
+// PaymentNotification — подтвержденное провайдером изменение платежа.
+// Значимы статусы PaymentPaid и PaymentFailed, остальные сервис пропускает.
+type PaymentNotification struct {
+	ExternalID string
+	Status     string
+}
+
+// Deposit возвращает сумму предоплаты за бронь: депозит за каждого гостя берется
+// с больших компаний и с броней, начинающихся в часы пик. 0 — предоплата не нужна.
+func (p BookingPolicy) Deposit(rv Reservation, loc *time.Location) int64 {
+	if p.DepositPerGuest <= 0 {
+		return 0
+	}
+	large := p.DepositPartySize > 0 && rv.Capacity >= p.DepositPartySize
+	if !large && !p.isPeak(rv.StartTime.In(loc)) {
+		return 0
+	}
+	return p.DepositPerGuest * int64(rv.Capacity)
+}
+
+// isPeak проверяет, что момент local попадает в ежедневные часы пик.
+// PeakEnd <= PeakStart означает, что часы пик продолжаются после полуночи.
+func (p BookingPolicy) isPeak(local time.Time) bool {
+	if p.PeakStart == p.PeakEnd {
+		return false
+	}
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if p.PeakStart < p.PeakEnd {
+		return clock >= p.PeakStart && clock < p.PeakEnd
+	}
+	return clock >= p.PeakStart || clock < p.PeakEnd
+}
+
+// PaymentHoldFor возвращает срок, в течение которого гость должен оплатить депозит.
+func (p BookingPolicy) PaymentHoldFor() time.Duration {
+	if p.PaymentHold > 0 {
+		return p.PaymentHold
+	}
+	return DefaultPaymentHold
+}
+
+// RefundDue сообщает, положен ли возврат депозита при отмене брони в момент now:
+// отменить нужно не позже чем за RefundWindow до начала.
+func (p BookingPolicy) RefundDue(rv Reservation, now time.Time) bool {
+	return !now.After(rv.StartTime.Add(-p.RefundWindow))
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+)
+
+// BookingPolicy — правила бронирования ресторана. Нулевое значение поля
+// означает отсутствие ограничения.
+type BookingPolicy struct {
+	RestaurantID        string
+	MinDuration         time.Duration // Минимальная длительность брони
+	MaxDuration         time.Duration // Максимальная длительность брони
+	SlotGranularity     time.Duration // Шаг сетки, к которой должны быть выровнены начало и конец брони
+	LeadTime            time.Duration // За сколько минимум до начала можно забронировать
+	BookingHorizon      time.Duration // На сколько максимум вперед можно забронировать
+	MaxTablesPerBooking int           // Максимум столиков в одной брони
+	MaxPartySize        int           // Максимум гостей в одной брони
+	TurnoverBuffer      time.Duration // Перерыв на уборку столика между бронями
+	EventMinDuration    time.Duration // Минимальная длительность брони зоны или выкупа ресторана
+	EventMaxDuration    time.Duration // Максимальная длительность брони зоны или выкупа ресторана
+	DepositPartySize    int           // С какого числа гостей нужна предоплата, 0 — только в часы пик
+	DepositPerGuest     int64         // Депозит за гостя в копейках, 0 — предоплата не нужна
+	PeakStart           time.Duration // Начало ежедневных часов пик от полуночи по местному времени
+	PeakEnd             time.Duration // Конец часов пик, равен PeakStart — часов пик нет
+	PaymentHold         time.Duration // Сколько ждать оплату депозита, 0 — DefaultPaymentHold
+	RefundWindow        time.Duration // За сколько до начала брони нужно отменить ее, чтобы вернуть депозит
+	CancellationCutoff  time.Duration // До какого момента перед началом отмена бесплатна, позже она считается поздней
+	MaxActiveBookings   int           // Сколько предстоящих броней может быть у гостя в ресторане одновременно
+	ApprovalHold        time.Duration // Сколько ждать решения менеджера по брони зоны или выкупу, 0 — DefaultApprovalHold
+}
+
+// DefaultMaxActiveBookings — сколько предстоящих броней может быть у гостя в ресторане без собственной политики.
+const DefaultMaxActiveBookings = 5
+
+// DefaultBookingPolicy возвращает правила для ресторанов без собственной политики.
+func DefaultBookingPolicy(restaurantID string) BookingPolicy {
+	return BookingPolicy{
+		RestaurantID:        restaurantID,
+		MaxDuration:         2 * time.Hour,
+		MaxTablesPerBooking: 4,
+		MaxActiveBookings:   DefaultMaxActiveBookings,
+		EventMaxDuration:    12 * time.Hour,
+	}
+}
+
+// Validate проверяет согласованность правил между собой.
+func (p BookingPolicy) Validate() error {
+	if p.MaxDuration > 0 && p.MaxDuration < p.MinDuration {
+		return ErrInvalidPolicy.Withf("максимальная длительность брони меньше минимальной")
+	}
+	if p.EventMaxDuration > 0 && p.EventMaxDuration < p.EventMinDuration {
+		return ErrInvalidPolicy.Withf("максимальная длительность мероприятия меньше минимальной")
+	}
+	if p.SlotGranularity > 0 && p.MinDuration%p.SlotGranularity != 0 {
+		return ErrInvalidPolicy.Withf("минимальная длительность брони должна быть кратна шагу сетки")
+	}
+	return nil
+}
+
+// EventPolicy возвращает правила для брони зоны целиком или выкупа ресторана (zone == nil).
+// Длительность ограничивается лимитами мероприятий, а лимиты зоны, если заданы, важнее них.
+// Ограничения на число столиков и гостей не действуют: бронь и так занимает всю зону.
+func (p BookingPolicy) EventPolicy(zone *Zone) BookingPolicy {
+	event := p
+	event.MinDuration = p.EventMinDuration
+	event.MaxDuration = p.EventMaxDuration
+	event.MaxTablesPerBooking = 0
+	event.MaxPartySize = 0
+	if zone != nil {
+		if zone.MinDuration > 0 {
+			event.MinDuration = zone.MinDuration
+		}
+		if zone.MaxDuration > 0 {
+			event.MaxDuration = zone.MaxDuration
+		}
+	}
+	return event
+}
+
+// RestaurantStaff связывает пользователя с рестораном, в котором он работает.
+type RestaurantStaff struct {
+	RestaurantID string
+	UserID       string
+	Role         string
+}
+
+const (
+	StaffRoleManager = "manager" // Настраивает ресторан и управляет бронями
+	StaffRoleHost    = "host"    // Встречает и рассаживает гостей
+)
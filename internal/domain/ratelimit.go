@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+)
+
+// RateLimit — ограничение частоты запросов корзиной токенов: подряд можно сделать
+// Burst запросов, дальше корзина пополняется со скоростью Burst запросов за Period.
+type RateLimit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// TokenBucket — состояние корзины токенов одного клиента.
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time // Нулевое значение — новая, полная корзина
+}
+
+// Enabled сообщает, что ограничение задано.
+func (l RateLimit) Enabled() bool {
+	return l.Burst > 0 && l.Period > 0
+}
+
+// Take пополняет корзину к моменту now и забирает из нее токен. Если токена нет,
+// корзина не меняется, а третьим значением возвращается время до появления токена.
+func (l RateLimit) Take(b TokenBucket, now time.Time) (TokenBucket, bool, time.Duration) {
+	tokens := float64(l.Burst)
+	if !b.UpdatedAt.IsZero() {
+		tokens = b.Tokens + now.Sub(b.UpdatedAt).Seconds()*l.rate()
+		if tokens > float64(l.Burst) {
+			tokens = float64(l.Burst)
+		}
+	}
+	if tokens < 1 {
+		wait := time.Duration((1 - tokens) / l.rate() * float64(time.Second))
+		return b, false, wait
+	}
+	return TokenBucket{Tokens: tokens - 1, UpdatedAt: now}, true, 0
+}
+
+// FullAt возвращает момент, когда корзина снова заполнится. После него ее состояние
+// можно забыть: новая корзина ведет себя так же.
+func (l RateLimit) FullAt(b TokenBucket) time.Time {
+	missing := float64(l.Burst) - b.Tokens
+	return b.UpdatedAt.Add(time.Duration(missing / l.rate() * float64(time.Second)))
+}
+
+// rate — скорость пополнения корзины в токенах в секунду.
+func (l RateLimit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitTake(t *testing.T) {
+	limit := RateLimit{Burst: 2, Period: time.Minute}
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		bucket    TokenBucket
+		at        time.Time
+		wantOK    bool
+		wantLeft  float64
+		wantRetry time.Duration
+	}{
+		{name: "new bucket is full", at: now, wantOK: true, wantLeft: 1},
+		{name: "last token", bucket: TokenBucket{Tokens: 1, UpdatedAt: now}, at: now, wantOK: true, wantLeft: 0},
+		{name: "empty bucket waits for a token", bucket: TokenBucket{Tokens: 0, UpdatedAt: now}, at: now, wantRetry: 30 * time.Second},
+		{name: "partial refill shortens the wait", bucket: TokenBucket{Tokens: 0, UpdatedAt: now}, at: now.Add(20 * time.Second), wantRetry: 10 * time.Second},
+		{name: "refill gives a token", bucket: TokenBucket{Tokens: 0, UpdatedAt: now}, at: now.Add(45 * time.Second), wantOK: true, wantLeft: 0.5},
+		{name: "refill is capped by burst", bucket: TokenBucket{Tokens: 0, UpdatedAt: now}, at: now.Add(time.Hour), wantOK: true, wantLeft: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok, retry := limit.Take(tt.bucket, tt.at)
+			if ok != tt.wantOK {
+				t.Fatalf("allowed = %v, want %v", ok, tt.wantOK)
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("retry after = %s, want %s", retry, tt.wantRetry)
+			}
+			if !ok {
+				if next != tt.bucket {
+					t.Errorf("rejected take changed the bucket: %+v", next)
+				}
+				return
+			}
+			if next.Tokens != tt.wantLeft || !next.UpdatedAt.Equal(tt.at) {
+				t.Errorf("bucket = %+v, want %v tokens at %s", next, tt.wantLeft, tt.at)
+			}
+		})
+	}
+}
+
+func TestRateLimitFullAt(t *testing.T) {
+	limit := RateLimit{Burst: 4, Period: time.Minute}
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		bucket TokenBucket
+		want   time.Time
+	}{
+		{name: "full bucket", bucket: TokenBucket{Tokens: 4, UpdatedAt: now}, want: now},
+		{name: "one token missing", bucket: TokenBucket{Tokens: 3, UpdatedAt: now}, want: now.Add(15 * time.Second)},
+		{name: "empty bucket", bucket: TokenBucket{Tokens: 0, UpdatedAt: now}, want: now.Add(time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limit.FullAt(tt.bucket); !got.Equal(tt.want) {
+				t.Errorf("full at %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
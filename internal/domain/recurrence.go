@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+)
+
+// Частота повторения серии броней.
+const (
+	RecurrenceWeekly   = "weekly"
+	RecurrenceBiweekly = "biweekly"
+	RecurrenceMonthly  = "monthly"
+)
+
+// MaxOccurrences — сколько броней может породить одна серия.
+const MaxOccurrences = 52
+
+// RecurrenceRule — упрощенное правило повторения (подмножество RRULE).
+// Задается ровно одно из ограничений: Until или Count.
+type RecurrenceRule struct {
+	Frequency string
+	Until     time.Time // Последний момент, на который может начаться бронь серии
+	Count     int       // Число броней в серии
+}
+
+// ReservationSeries — серия повторяющихся броней одного гостя.
+type ReservationSeries struct {
+	ID           string
+	UserID       string
+	RestaurantID string
+	Rule         RecurrenceRule
+	CreatedAt    time.Time
+}
+
+// Validate проверяет правило повторения.
+func (r RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case RecurrenceWeekly, RecurrenceBiweekly, RecurrenceMonthly:
+	default:
+		return ErrInvalidRecurrence.Withf("unknown frequency %q", r.Frequency)
+	}
+	if r.Until.IsZero() == (r.Count == 0) {
+		return ErrInvalidRecurrence.Withf("exactly one of until or count must be set")
+	}
+	if r.Count < 0 || r.Count > MaxOccurrences {
+		return ErrInvalidRecurrence.Withf("count must be between 1 and %d", MaxOccurrences)
+	}
+	return nil
+}
+
+// Occurrences раскладывает интервал [start, end) по правилу. Шаг считается по календарю
+// в часовом поясе ресторана loc, поэтому бронь остается в то же местное время после перехода
+// на летнее время. Месяцы без нужного числа (31-е в апреле) пропускаются, как в RRULE.
+func (r RecurrenceRule) Occurrences(start, end time.Time, loc *time.Location) ([][2]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if !r.Until.IsZero() && r.Until.Before(start) {
+		return nil, ErrInvalidRecurrence.Withf("until is before the first reservation")
+	}
+	duration := end.Sub(start)
+	local := start.In(loc)
+
+	var result [][2]time.Time
+	for i := 0; ; i++ {
+		var next time.Time
+		switch r.Frequency {
+		case RecurrenceWeekly:
+			next = local.AddDate(0, 0, 7*i)
+		case RecurrenceBiweekly:
+			next = local.AddDate(0, 0, 14*i)
+		case RecurrenceMonthly:
+			next = local.AddDate(0, i, 0)
+			if next.Day() != local.Day() {
+				continue
+			}
+		}
+		if !r.Until.IsZero() && next.After(r.Until) {
+			break
+		}
+		if len(result) == MaxOccurrences {
+			return nil, ErrInvalidRecurrence.Withf("series may not have more than %d reservations", MaxOccurrences)
+		}
+		result = append(result, [2]time.Time{next, next.Add(duration)})
+		if r.Count > 0 && len(result) == r.Count {
+			break
+		}
+	}
+	return result, nil
+}
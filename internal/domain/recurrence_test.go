@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	loc := berlin(t)
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, loc)
+	}
+	const layout = "2006-01-02 15:04 MST"
+
+	tests := []struct {
+		name  string
+		rule  RecurrenceRule
+		start time.Time
+		want  []string // Начала броней в местном времени
+	}{
+		{
+			name:  "weekly by count",
+			rule:  RecurrenceRule{Frequency: RecurrenceWeekly, Count: 3},
+			start: at(2026, time.June, 1, 19),
+			want:  []string{"2026-06-01 19:00 CEST", "2026-06-08 19:00 CEST", "2026-06-15 19:00 CEST"},
+		},
+		{
+			name:  "weekly until is inclusive",
+			rule:  RecurrenceRule{Frequency: RecurrenceWeekly, Until: at(2026, time.June, 15, 19)},
+			start: at(2026, time.June, 1, 19),
+			want:  []string{"2026-06-01 19:00 CEST", "2026-06-08 19:00 CEST", "2026-06-15 19:00 CEST"},
+		},
+		{
+			name:  "biweekly by count",
+			rule:  RecurrenceRule{Frequency: RecurrenceBiweekly, Count: 3},
+			start: at(2026, time.June, 1, 19),
+			want:  []string{"2026-06-01 19:00 CEST", "2026-06-15 19:00 CEST", "2026-06-29 19:00 CEST"},
+		},
+		{
+			name:  "biweekly until",
+			rule:  RecurrenceRule{Frequency: RecurrenceBiweekly, Until: at(2026, time.July, 12, 0)},
+			start: at(2026, time.June, 1, 19),
+			want:  []string{"2026-06-01 19:00 CEST", "2026-06-15 19:00 CEST", "2026-06-29 19:00 CEST"},
+		},
+		{
+			name:  "monthly by count",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Count: 3},
+			start: at(2026, time.June, 10, 19),
+			want:  []string{"2026-06-10 19:00 CEST", "2026-07-10 19:00 CEST", "2026-08-10 19:00 CEST"},
+		},
+		{
+			name:  "monthly until",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Until: at(2026, time.September, 9, 0)},
+			start: at(2026, time.June, 10, 19),
+			want:  []string{"2026-06-10 19:00 CEST", "2026-07-10 19:00 CEST", "2026-08-10 19:00 CEST"},
+		},
+		{
+			name:  "monthly on the 31st skips short months",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Count: 4},
+			start: at(2026, time.January, 31, 19),
+			want:  []string{"2026-01-31 19:00 CET", "2026-03-31 19:00 CEST", "2026-05-31 19:00 CEST", "2026-07-31 19:00 CEST"},
+		},
+		{
+			name:  "monthly on the 31st until skips short months",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Until: at(2026, time.June, 30, 0)},
+			start: at(2026, time.March, 31, 19),
+			want:  []string{"2026-03-31 19:00 CEST", "2026-05-31 19:00 CEST"},
+		},
+		{
+			name:  "monthly from Feb 29 of a leap year",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Count: 3},
+			start: at(2028, time.February, 29, 19),
+			want:  []string{"2028-02-29 19:00 CET", "2028-03-29 19:00 CEST", "2028-04-29 19:00 CEST"},
+		},
+		{
+			name:  "monthly on the 29th skips Feb of a common year",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Count: 3},
+			start: at(2027, time.January, 29, 19),
+			want:  []string{"2027-01-29 19:00 CET", "2027-03-29 19:00 CEST", "2027-04-29 19:00 CEST"},
+		},
+		{
+			name:  "monthly on the 29th keeps Feb of a leap year",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Count: 2},
+			start: at(2028, time.January, 29, 19),
+			want:  []string{"2028-01-29 19:00 CET", "2028-02-29 19:00 CET"},
+		},
+		{
+			name:  "weekly keeps local time across spring forward",
+			rule:  RecurrenceRule{Frequency: RecurrenceWeekly, Count: 2},
+			start: at(2026, time.March, 22, 19),
+			want:  []string{"2026-03-22 19:00 CET", "2026-03-29 19:00 CEST"},
+		},
+		{
+			name:  "biweekly keeps local time across fall back",
+			rule:  RecurrenceRule{Frequency: RecurrenceBiweekly, Count: 2},
+			start: at(2026, time.October, 18, 19),
+			want:  []string{"2026-10-18 19:00 CEST", "2026-11-01 19:00 CET"},
+		},
+		{
+			name:  "monthly keeps local time across fall back",
+			rule:  RecurrenceRule{Frequency: RecurrenceMonthly, Count: 2},
+			start: at(2026, time.October, 10, 19),
+			want:  []string{"2026-10-10 19:00 CEST", "2026-11-10 19:00 CET"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Начало передается в UTC, как его присылает клиент: шаг должен считаться в loc.
+			start := tt.start.UTC()
+			occurrences, err := tt.rule.Occurrences(start, start.Add(2*time.Hour), loc)
+			if err != nil {
+				t.Fatalf("Occurrences: %v", err)
+			}
+			if len(occurrences) != len(tt.want) {
+				t.Fatalf("got %d occurrences, want %d: %v", len(occurrences), len(tt.want), occurrences)
+			}
+			for i, want := range tt.want {
+				if got := occurrences[i][0].In(loc).Format(layout); got != want {
+					t.Errorf("occurrence %d starts %s, want %s", i, got, want)
+				}
+				if d := occurrences[i][1].Sub(occurrences[i][0]); d != 2*time.Hour {
+					t.Errorf("occurrence %d lasts %s, want 2h", i, d)
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrencesErrors(t *testing.T) {
+	start := time.Date(2026, time.June, 1, 19, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		rule RecurrenceRule
+	}{
+		{"unknown frequency", RecurrenceRule{Frequency: "daily", Count: 2}},
+		{"neither count nor until", RecurrenceRule{Frequency: RecurrenceWeekly}},
+		{"both count and until", RecurrenceRule{Frequency: RecurrenceWeekly, Count: 2, Until: start.AddDate(0, 1, 0)}},
+		{"count above limit", RecurrenceRule{Frequency: RecurrenceWeekly, Count: MaxOccurrences + 1}},
+		{"until before start", RecurrenceRule{Frequency: RecurrenceWeekly, Until: start.Add(-time.Hour)}},
+		{"until yields too many", RecurrenceRule{Frequency: RecurrenceWeekly, Until: start.AddDate(2, 0, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.rule.Occurrences(start, start.Add(time.Hour), time.UTC)
+			if !errors.Is(err, ErrInvalidRecurrence) {
+				t.Fatalf("err = %v, want ErrInvalidRecurrence", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// OpeningHours — интервал работы ресторана в один из дней недели.
+// Open и Close отсчитываются от полуночи. Close <= Open означает, что ресторан
+// закрывается уже на следующие сутки (например, с 18:00 до 02:00).
+type OpeningHours struct {
+	RestaurantID string
+	Weekday      time.Weekday
+	Open         time.Duration
+	Close        time.Duration
+}
+
+// SpecialDay переопределяет недельное расписание на конкретную дату (праздник, короткий день).
+type SpecialDay struct {
+	RestaurantID string
+	Date         time.Time // Полночь нужной даты, используются только год, месяц и день
+	Closed       bool
+	Open         time.Duration
+	Close        time.Duration
+	Note         string
+}
+
+// Closure — внеплановое закрытие ресторана на произвольный интервал.
+type Closure struct {
+	ID           string
+	RestaurantID string
+	StartTime    time.Time
+	EndTime      time.Time
+	Reason       string
+}
+
+// Schedule — расписание ресторана. Ресторан без недельного расписания считается
+// открытым круглосуточно, чтобы не ломать бронирование до настройки часов работы.
+type Schedule struct {
+	Weekly      []OpeningHours
+	SpecialDays []SpecialDay
+	Closures    []Closure
+}
+
+// IsOpen проверяет, что интервал [start, end) целиком приходится на часы работы
+// и не пересекается с закрытиями. Часы работы трактуются в часовом поясе loc.
+// Смены, идущие встык или внахлест, считаются одним интервалом работы.
+func (s Schedule) IsOpen(start, end time.Time, loc *time.Location) bool {
+	for _, c := range s.Closures {
+		if start.Before(c.EndTime) && end.After(c.StartTime) {
+			return false
+		}
+	}
+	if len(s.Weekly) == 0 && len(s.SpecialDays) == 0 {
+		return true
+	}
+
+	// Бронь могла начаться в смену, открывшуюся накануне и идущую после полуночи,
+	// и закончиться в смену следующего дня.
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	var intervals [][2]time.Time
+	for d := day.AddDate(0, 0, -1); d.Before(end); d = d.AddDate(0, 0, 1) {
+		intervals = append(intervals, s.intervals(d)...)
+	}
+	for _, interval := range mergeIntervals(intervals) {
+		if !start.Before(interval[0]) && !end.After(interval[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeIntervals сортирует интервалы по началу и склеивает пересекающиеся и идущие встык.
+func mergeIntervals(intervals [][2]time.Time) [][2]time.Time {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i][0].Before(intervals[j][0]) })
+	var merged [][2]time.Time
+	for _, interval := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !interval[0].After(merged[last][1]) {
+			if interval[1].After(merged[last][1]) {
+				merged[last][1] = interval[1]
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// intervals возвращает интервалы работы, открывающиеся в день day.
+func (s Schedule) intervals(day time.Time) [][2]time.Time {
+	for _, sd := range s.SpecialDays {
+		if sameDate(sd.Date, day) {
+			if sd.Closed {
+				return nil
+			}
+			return [][2]time.Time{shift(day, sd.Open, sd.Close)}
+		}
+	}
+	var result [][2]time.Time
+	for _, h := range s.Weekly {
+		if h.Weekday == day.Weekday() {
+			result = append(result, shift(day, h.Open, h.Close))
+		}
+	}
+	return result
+}
+
+// shift переводит смещения от полуночи в абсолютные моменты времени. Сложение
+// идет по календарю, а не по длительности, чтобы переход на летнее время не сдвигал часы работы.
+func shift(day time.Time, open, close time.Duration) [2]time.Time {
+	from := atClock(day, open)
+	to := atClock(day, close)
+	if close <= open {
+		to = atClock(day.AddDate(0, 0, 1), close)
+	}
+	return [2]time.Time{from, to}
+}
+
+func atClock(day time.Time, offset time.Duration) time.Time {
+	minutes := int(offset / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// everyDay задает одинаковые часы работы на всю неделю.
+func everyDay(open, close time.Duration) []OpeningHours {
+	hours := make([]OpeningHours, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		hours = append(hours, OpeningHours{Weekday: d, Open: open, Close: close})
+	}
+	return hours
+}
+
+func TestScheduleIsOpenAcrossDST(t *testing.T) {
+	loc := berlin(t)
+	daytime := Schedule{Weekly: everyDay(10*time.Hour, 22*time.Hour)}
+	overnight := Schedule{Weekly: everyDay(20*time.Hour, 3*time.Hour)}
+	closedSunday := Schedule{
+		Weekly:      everyDay(0, 24*time.Hour-time.Minute),
+		SpecialDays: []SpecialDay{{Date: time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC), Closed: true}},
+	}
+
+	tests := []struct {
+		name       string
+		schedule   Schedule
+		start, end string
+		want       bool
+	}{
+		// В день перехода на летнее время 10:00 по местному — это 08:00 UTC, а не 09:00.
+		{"opening on spring forward day", daytime, "2026-03-29T08:00:00Z", "2026-03-29T09:00:00Z", true},
+		{"before opening on spring forward day", daytime, "2026-03-29T07:30:00Z", "2026-03-29T08:30:00Z", false},
+		{"opening the day before spring forward", daytime, "2026-03-28T09:00:00Z", "2026-03-28T10:00:00Z", true},
+		// Ночная смена с субботы закрывается в 03:00 CEST, то есть длится на час меньше.
+		{"overnight shift across spring forward", overnight, "2026-03-29T00:30:00Z", "2026-03-29T01:00:00Z", true},
+		{"overnight shift after closing", overnight, "2026-03-29T00:30:00Z", "2026-03-29T01:30:00Z", false},
+		// В день перехода на зимнее время 10:00 по местному — это 09:00 UTC.
+		{"opening on fall back day", daytime, "2026-10-25T09:00:00Z", "2026-10-25T10:00:00Z", true},
+		{"before opening on fall back day", daytime, "2026-10-25T08:30:00Z", "2026-10-25T09:30:00Z", false},
+		// Ночная смена с субботы закрывается в 03:00 CET и длится на час дольше.
+		{"repeated hour inside overnight shift", overnight, "2026-10-25T00:00:00Z", "2026-10-25T02:00:00Z", true},
+		{"overnight shift after fall back closing", overnight, "2026-10-25T01:30:00Z", "2026-10-25T02:30:00Z", false},
+		// День недели и особые дни определяются по местной дате: 23:30 UTC субботы — это уже воскресенье.
+		{"local date picks the special day", closedSunday, "2026-03-28T23:30:00Z", "2026-03-29T00:30:00Z", false},
+		{"last local hour before the special day", closedSunday, "2026-03-28T21:30:00Z", "2026-03-28T22:30:00Z", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.IsOpen(utc(tt.start), utc(tt.end), loc); got != tt.want {
+				t.Errorf("IsOpen(%s, %s) = %v, want %v", utc(tt.start).In(loc).Format("Mon 15:04 MST"), utc(tt.end).In(loc).Format("Mon 15:04 MST"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleIsOpenAcrossAdjacentShifts(t *testing.T) {
+	loc := berlin(t)
+	allDay := Schedule{Weekly: everyDay(0, 0)}
+	// Пятница 18:00–00:00 и суббота 00:00–02:00 записаны разными строками, но идут встык.
+	split := Schedule{Weekly: []OpeningHours{
+		{Weekday: time.Friday, Open: 18 * time.Hour, Close: 0},
+		{Weekday: time.Saturday, Open: 0, Close: 2 * time.Hour},
+	}}
+	overlapping := Schedule{Weekly: []OpeningHours{
+		{Weekday: time.Friday, Open: 12 * time.Hour, Close: 16 * time.Hour},
+		{Weekday: time.Friday, Open: 15 * time.Hour, Close: 20 * time.Hour},
+	}}
+	gap := Schedule{Weekly: []OpeningHours{
+		{Weekday: time.Friday, Open: 12 * time.Hour, Close: 15 * time.Hour},
+		{Weekday: time.Friday, Open: 16 * time.Hour, Close: 20 * time.Hour},
+	}}
+	closedSaturday := Schedule{
+		Weekly:      everyDay(0, 0),
+		SpecialDays: []SpecialDay{{Date: time.Date(2026, 6, 6, 0, 0, 0, 0, time.UTC), Closed: true}},
+	}
+
+	// 2026-06-05 — пятница, в Берлине действует CEST (UTC+2).
+	tests := []struct {
+		name       string
+		schedule   Schedule
+		start, end string
+		want       bool
+	}{
+		{"24h restaurant across midnight", allDay, "2026-06-05T21:00:00Z", "2026-06-05T23:00:00Z", true},
+		{"24h restaurant for a whole day", allDay, "2026-06-05T10:00:00Z", "2026-06-06T10:00:00Z", true},
+		{"24h restaurant across spring forward", allDay, "2026-03-28T22:00:00Z", "2026-03-29T02:00:00Z", true},
+		{"split rows across midnight", split, "2026-06-05T21:00:00Z", "2026-06-05T23:00:00Z", true},
+		{"split rows past closing", split, "2026-06-05T23:00:00Z", "2026-06-06T00:30:00Z", false},
+		{"overlapping shifts", overlapping, "2026-06-05T12:00:00Z", "2026-06-05T16:00:00Z", true},
+		{"gap between shifts", gap, "2026-06-05T12:00:00Z", "2026-06-05T15:00:00Z", false},
+		{"24h restaurant into closed special day", closedSaturday, "2026-06-05T21:00:00Z", "2026-06-05T23:00:00Z", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.IsOpen(utc(tt.start), utc(tt.end), loc); got != tt.want {
+				t.Errorf("IsOpen(%s, %s) = %v, want %v", utc(tt.start).In(loc).Format("Mon 15:04 MST"), utc(tt.end).In(loc).Format("Mon 15:04 MST"), got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+)
+
+// Статусы записи в листе ожидания.
+const (
+	WaitlistWaiting  = "waiting"  // Гость ждет освобождения столика
+	WaitlistOffered  = "offered"  // Гостю предложены столики, они придержаны до OfferExpiresAt
+	WaitlistClaimed  = "claimed"  // Гость принял предложение, создана бронь
+	WaitlistExpired  = "expired"  // Желаемое время прошло, предложение так и не поступило
+	WaitlistCanceled = "canceled" // Гость сам покинул лист ожидания
+)
+
+// WaitlistEntry — желание гостя получить столик в занятое время.
+type WaitlistEntry struct {
+	ID             string
+	UserID         string
+	RestaurantID   string
+	StartTime      time.Time
+	EndTime        time.Time
+	Capacity       int
+	Contacts       Contacts
+	Status         string
+	OfferedTables  []string  // Столики, придержанные для гостя
+	OfferExpiresAt time.Time // До какого момента гость может принять предложение
+	ReservationID  string    // Бронь, созданная при принятии предложения
+	CreatedAt      time.Time
+}
+
+// Reservation возвращает бронь, которую создаст принятие предложения.
+func (e WaitlistEntry) Reservation() Reservation {
+	return Reservation{
+		UserID:       e.UserID,
+		RestaurantID: e.RestaurantID,
+		StartTime:    e.StartTime,
+		EndTime:      e.EndTime,
+		Status:       "wait",
+		Capacity:     e.Capacity,
+		Contacts:     e.Contacts,
+	}
+}
+
+// OfferActive сообщает, может ли гость принять предложение в момент now.
+func (e WaitlistEntry) OfferActive(now time.Time) bool {
+	return e.Status == WaitlistOffered && now.Before(e.OfferExpiresAt)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+)
+
+// Охват брони.
+const (
+	ReservationScopeTables = "tables" // Отдельные столики
+	ReservationScopeZone   = "zone"   // Зона ресторана целиком
+	ReservationScopeVenue  = "venue"  // Выкуп всего ресторана
+)
+
+// DefaultApprovalHold — сколько бронь зоны или выкуп ждут решения менеджера, если ресторан не задал срок.
+const DefaultApprovalHold = 24 * time.Hour
+
+// Zone — именованная группа столиков ресторана (терраса, зал, VIP-комната),
+// которую можно забронировать целиком.
+type Zone struct {
+	ID               string
+	RestaurantID     string
+	Name             string
+	TableIDs         []string
+	MinDuration      time.Duration // Переопределяет минимальную длительность мероприятия, 0 — по правилам ресторана
+	MaxDuration      time.Duration // Переопределяет максимальную длительность мероприятия, 0 — по правилам ресторана
+	RequiresApproval bool          // Бронь зоны гостем подтверждает менеджер
+}
+
+// Validate проверяет описание зоны.
+func (z Zone) Validate() error {
+	if z.Name == "" {
+		return ErrInvalidZone.Withf("zone name is required")
+	}
+	if len(z.TableIDs) == 0 {
+		return ErrInvalidZone.Withf("zone must contain at least one table")
+	}
+	if z.MaxDuration > 0 && z.MaxDuration < z.MinDuration {
+		return ErrInvalidZone.Withf("максимальная длительность брони зоны меньше минимальной")
+	}
+	return nil
+}
+
+// IsEvent сообщает, что бронь занимает зону или весь ресторан.
+func (rv Reservation) IsEvent() bool {
+	return rv.Scope == ReservationScopeZone || rv.Scope == ReservationScopeVenue
+}
+
+// ApprovalDeadline возвращает, до какого момента менеджер должен подтвердить бронь rv,
+// созданную в now. Столики не держатся дольше начала брони.
+func (p BookingPolicy) ApprovalDeadline(rv Reservation, now time.Time) time.Time {
+	hold := p.ApprovalHold
+	if hold <= 0 {
+		hold = DefaultApprovalHold
+	}
+	deadline := now.Add(hold)
+	if rv.StartTime.Before(deadline) {
+		return rv.StartTime
+	}
+	return deadline
+}
+
+// PendingApproval сообщает, что бронь зоны или выкуп ждет решения менеджера.
+func (rv Reservation) PendingApproval() bool {
+	return rv.IsEvent() && rv.Status == ReservationWait
+}
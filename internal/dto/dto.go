@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -49,6 +50,69 @@ type ReservationDTO struct {
 	Table        []TableDTO  `json:"table"`
 	Contacts     ContactsDTO `json:"contacts"`
 	Capacity     int         `json:"capacity"`
+	Source       string      `json:"source"` // online или walk_in
+	SeatedAt     *time.Time  `json:"seated_at,omitempty"`
+	LeftAt       *time.Time  `json:"left_at,omitempty"`
+	SeriesID     string      `json:"series_id,omitempty"`
+	Scope        string      `json:"scope"` // tables, zone или venue
+	ZoneID       string      `json:"zone_id,omitempty"`
+	Payment      *PaymentDTO `json:"payment,omitempty"`
+	CanceledAt   *time.Time  `json:"canceled_at,omitempty"`
+	LateCancel   bool        `json:"late_cancel,omitempty"`
+	NoShow       bool        `json:"no_show,omitempty"`
+	Version      int64       `json:"version"` // Версия брони, она же ETag
+	// До какого момента менеджер должен подтвердить бронь зоны или выкуп
+	ApprovalExpiresAt *time.Time `json:"approval_expires_at,omitempty"`
+}
+
+// GuestReliabilityDTO — надежность гостя для персонала ресторана.
+type GuestReliabilityDTO struct {
+	UserID      string `json:"user_id"`
+	Visits      int    `json:"visits"`
+	NoShows     int    `json:"no_shows"`
+	LateCancels int    `json:"late_cancels"`
+	Score       int    `json:"score"` // От 0 до 100
+}
+
+// AuditEntryDTO — запись журнала аудита ресторана.
+type AuditEntryDTO struct {
+	ID           string                    `json:"id"`
+	RestaurantID string                    `json:"restaurant_id"`
+	EntityType   string                    `json:"entity_type"` // reservation, table или restaurant
+	EntityID     string                    `json:"entity_id"`
+	Action       string                    `json:"action"`
+	ActorID      string                    `json:"actor_id"` // ID пользователя или system
+	RequestID    string                    `json:"request_id,omitempty"`
+	Changes      map[string]AuditChangeDTO `json:"changes"`
+	CreatedAt    time.Time                 `json:"created_at"`
+}
+
+// AuditChangeDTO — значение поля до и после изменения.
+type AuditChangeDTO struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// AuditFilterDTO — условия выборки журнала аудита.
+type AuditFilterDTO struct {
+	RestaurantID string
+	EntityType   string
+	EntityID     string
+	ActorID      string
+	From         time.Time
+	To           time.Time
+	Limit        int
+	Offset       int
+}
+
+// PaymentDTO — депозит за бронь. Сумма в минимальных единицах валюты.
+type PaymentDTO struct {
+	ID              string    `json:"id"`
+	Amount          int64     `json:"amount"`
+	Currency        string    `json:"currency"`
+	Status          string    `json:"status"` // pending, paid, failed, expired, canceled, refunded
+	ConfirmationURL string    `json:"confirmation_url,omitempty"`
+	ExpiresAt       time.Time `json:"expires_at"`
 }
 
 type ContactsDTO struct {
@@ -58,5 +122,172 @@ type ContactsDTO struct {
 
 type AvaibleTableDTO struct {
 	TableDTO
-	IsAvaible bool `json:"is_avaible"`
+	IsAvaible  bool `json:"is_avaible"`
+	IsOccupied bool `json:"is_occupied"`
+}
+
+// RescheduleDTO — новое время и столики брони. Пустой Table оставляет столики прежними.
+type RescheduleDTO struct {
+	ReservationID string    `json:"reservation_id"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Table         []string  `json:"table"`
+}
+
+// WalkInDTO — гости, пришедшие без брони и посаженные хостом.
+type WalkInDTO struct {
+	RestaurantID    string      `json:"restaurant_id"`
+	Table           []string    `json:"table"`
+	Capacity        int         `json:"capacity"`
+	DurationMinutes int         `json:"duration_minutes"` // Ожидаемое время за столом, 0 — по правилам ресторана
+	Contacts        ContactsDTO `json:"contacts"`
+}
+
+// BookingPolicyDTO — структура для передачи правил бронирования ресторана.
+// Длительности передаются в минутах, 0 — ограничение не задано.
+type BookingPolicyDTO struct {
+	RestaurantID              string `json:"restaurant_id"`
+	MinDurationMinutes        int    `json:"min_duration_minutes"`
+	MaxDurationMinutes        int    `json:"max_duration_minutes"`
+	SlotGranularityMinutes    int    `json:"slot_granularity_minutes"`
+	LeadTimeMinutes           int    `json:"lead_time_minutes"`
+	BookingHorizonMinutes     int    `json:"booking_horizon_minutes"`
+	MaxTablesPerBooking       int    `json:"max_tables_per_booking"`
+	MaxPartySize              int    `json:"max_party_size"`
+	TurnoverBufferMinutes     int    `json:"turnover_buffer_minutes"`
+	EventMinDurationMinutes   int    `json:"event_min_duration_minutes"`
+	EventMaxDurationMinutes   int    `json:"event_max_duration_minutes"`
+	DepositPartySize          int    `json:"deposit_party_size"`
+	DepositPerGuest           int64  `json:"deposit_per_guest"` // В копейках
+	PeakStartMinute           int    `json:"peak_start_minute"`
+	PeakEndMinute             int    `json:"peak_end_minute"`
+	PaymentHoldMinutes        int    `json:"payment_hold_minutes"`
+	RefundWindowMinutes       int    `json:"refund_window_minutes"`
+	CancellationCutoffMinutes int    `json:"cancellation_cutoff_minutes"`
+	MaxActiveBookings         int    `json:"max_active_bookings"`
+	ApprovalHoldMinutes       int    `json:"approval_hold_minutes"`
+}
+
+// OpeningHoursDTO — интервал работы ресторана в день недели.
+// Время задается в минутах от полуночи, close_minute <= open_minute — работа после полуночи.
+type OpeningHoursDTO struct {
+	Weekday     int `json:"weekday"` // 0 — воскресенье, 6 — суббота
+	OpenMinute  int `json:"open_minute"`
+	CloseMinute int `json:"close_minute"`
+}
+
+// SpecialDayDTO — переопределение расписания на конкретную дату.
+type SpecialDayDTO struct {
+	RestaurantID string    `json:"restaurant_id"`
+	Date         time.Time `json:"date"`
+	Closed       bool      `json:"closed"`
+	OpenMinute   int       `json:"open_minute"`
+	CloseMinute  int       `json:"close_minute"`
+	Note         string    `json:"note,omitempty"`
+}
+
+// ClosureDTO — внеплановое закрытие ресторана.
+type ClosureDTO struct {
+	ID           string    `json:"id"`
+	RestaurantID string    `json:"restaurant_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+// ScheduleDTO — расписание ресторана.
+type ScheduleDTO struct {
+	Weekly      []OpeningHoursDTO `json:"weekly"`
+	SpecialDays []SpecialDayDTO   `json:"special_days"`
+	Closures    []ClosureDTO      `json:"closures"`
+}
+
+// TableBlockDTO — блокировка столика персоналом ресторана.
+type TableBlockDTO struct {
+	ID           string    `json:"id"`
+	TableID      string    `json:"table_id"`
+	RestaurantID string    `json:"restaurant_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Reason       string    `json:"reason"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ZoneDTO — зона ресторана, которую можно забронировать целиком.
+type ZoneDTO struct {
+	ID                 string   `json:"id"`
+	RestaurantID       string   `json:"restaurant_id"`
+	Name               string   `json:"name"`
+	TableIDs           []string `json:"tables"`
+	MinDurationMinutes int      `json:"min_duration_minutes"`
+	MaxDurationMinutes int      `json:"max_duration_minutes"`
+	RequiresApproval   bool     `json:"requires_approval"`
+}
+
+// EventBookingDTO — бронь зоны целиком или выкуп ресторана, если ZoneID пуст.
+type EventBookingDTO struct {
+	UserID       string      `json:"user_id"`
+	RestaurantID string      `json:"restaurant_id"`
+	ZoneID       string      `json:"zone_id,omitempty"`
+	StartTime    time.Time   `json:"start_time"`
+	EndTime      time.Time   `json:"end_time"`
+	Capacity     int         `json:"capacity"`
+	Contacts     ContactsDTO `json:"contacts"`
+}
+
+// RecurrenceDTO — правило повторения брони. Задается либо Until, либо Count.
+type RecurrenceDTO struct {
+	Frequency string     `json:"frequency"` // weekly, biweekly, monthly
+	Until     *time.Time `json:"until,omitempty"`
+	Count     int        `json:"count,omitempty"`
+}
+
+// ReservationSeriesDTO — серия повторяющихся броней и брони, которые в нее вошли.
+type ReservationSeriesDTO struct {
+	ID           string                  `json:"id"`
+	UserID       string                  `json:"user_id"`
+	RestaurantID string                  `json:"restaurant_id"`
+	Recurrence   RecurrenceDTO           `json:"recurrence"`
+	Reservations []ReservationDTO        `json:"reservations"`
+	Conflicts    []OccurrenceConflictDTO `json:"conflicts,omitempty"`
+}
+
+// OccurrenceConflictDTO — повторение серии, которое не удалось забронировать, и причина.
+type OccurrenceConflictDTO struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Code      string    `json:"code"`
+	Message   string    `json:"message"`
+}
+
+// WaitlistEntryDTO — запись гостя в листе ожидания.
+type WaitlistEntryDTO struct {
+	ID             string      `json:"id"`
+	UserID         string      `json:"user_id"`
+	RestaurantID   string      `json:"restaurant_id"`
+	StartTime      time.Time   `json:"start_time"`
+	EndTime        time.Time   `json:"end_time"`
+	Capacity       int         `json:"capacity"`
+	Contacts       ContactsDTO `json:"contacts"`
+	Status         string      `json:"status"` // waiting, offered, claimed, expired, canceled
+	OfferedTables  []string    `json:"offered_tables,omitempty"`
+	OfferExpiresAt *time.Time  `json:"offer_expires_at,omitempty"`
+	ReservationID  string      `json:"reservation_id,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+// WaitlistOfferEvent — событие о предложении столика гостю из листа ожидания.
+// Telegram-бот доставляет его пользователю по TelegramID.
+type WaitlistOfferEvent struct {
+	Type         string    `json:"type"`
+	EntryID      string    `json:"entry_id"`
+	UserID       string    `json:"user_id"`
+	TelegramID   int64     `json:"telegram_id"`
+	RestaurantID string    `json:"restaurant_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Capacity     int       `json:"capacity"`
+	TableIDs     []string  `json:"table_ids"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (c *Controller) GetAuditLog(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data auditLogRequest
+	if err := context.ShouldBindQuery(&data); err != nil {
+		c.logger.Warn("Invalid audit log request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	entries, err := c.useCase.GetAuditLog(context.Request.Context(), userUUID.(string), dto.AuditFilterDTO{
+		RestaurantID: context.Param("restaurantId"),
+		EntityType:   data.EntityType,
+		EntityID:     data.EntityID,
+		ActorID:      data.ActorID,
+		From:         data.From,
+		To:           data.To,
+		Limit:        data.Limit,
+		Offset:       data.Offset,
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, entries, nil, nil, context, http.StatusOK)
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (c *Controller) GetTableBlocks(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	from, to, ok := dateRange(context)
+	if !ok {
+		return
+	}
+
+	blocks, err := c.useCase.GetTableBlocks(context.Request.Context(), userUUID.(string), restaurantId, from, to)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, blocks, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) CreateTableBlock(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data tableBlockRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid table block request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	block, err := c.useCase.CreateTableBlock(context.Request.Context(), userUUID.(string), dto.TableBlockDTO{
+		TableID:      context.Param("tableId"),
+		RestaurantID: restaurantId,
+		StartTime:    data.StartTime,
+		EndTime:      data.EndTime,
+		Reason:       data.Reason,
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, block, nil, nil, context, http.StatusCreated)
+}
+
+func (c *Controller) DeleteTableBlock(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	if err := c.useCase.DeleteTableBlock(context.Request.Context(), userUUID.(string), restaurantId, context.Param("blockId")); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, "Table block deleted", nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) DeleteTable(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	if err := c.useCase.DeleteTable(context.Request.Context(), userUUID.(string), restaurantId, context.Param("tableId")); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, "Table deleted", nil, nil, context, http.StatusOK)
+}
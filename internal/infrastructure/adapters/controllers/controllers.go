@@ -3,14 +3,29 @@ package controllers
 import (
 	"booking_system/cmd/providers/middelware"
 	"booking_system/internal/app/ports"
+	"booking_system/internal/domain"
 	"booking_system/internal/dto"
 	"github.com/gin-gonic/gin"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Заголовки идемпотентного создания брони.
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// Заголовки условного изменения брони. ETag брони — ее версия в кавычках.
+const (
+	etagHeader    = "ETag"
+	ifMatchHeader = "If-Match"
+)
+
 type Controller struct {
 	logger  *slog.Logger
 	useCase ports.IUseCase
@@ -18,6 +33,7 @@ type Controller struct {
 }
 
 func New(logger *slog.Logger, useCase ports.IUseCase, jwt *middelware.Jwt) *Controller {
+	registerValidators()
 	return &Controller{
 		logger:  logger,
 		useCase: useCase,
@@ -34,7 +50,7 @@ func (c *Controller) Authorize(context *gin.Context) {
 		"auth_date":  context.Query("auth_date"),
 		"hash":       context.Query("hash"),
 	}
-	c.logger.Info("data", data)
+	c.logger.Info("Telegram auth data", "data", data)
 
 	if data["hash"] == "" {
 		c.logger.Warn("Telegram hash is missing")
@@ -42,9 +58,9 @@ func (c *Controller) Authorize(context *gin.Context) {
 		return
 	}
 
-	if ok, err := c.useCase.ValidateTelegramHash(data["hash"], data); !ok || err != nil {
+	if ok, err := c.useCase.ValidateTelegramHash(context.Request.Context(), data["hash"], data); !ok || err != nil {
 		if err != nil {
-			c.logger.Error("Error validating telegram hash", err)
+			c.logger.Error("Error validating telegram hash", "error", err)
 			response(false, nil, "Error validating telegram hash", nil, context, http.StatusBadRequest)
 			return
 		}
@@ -62,10 +78,9 @@ func (c *Controller) Authorize(context *gin.Context) {
 		Name:       data["username"],
 		TelegramID: int64(telegramId),
 	}
-	createUser, token, err := c.useCase.AuthUser(dtoUser)
+	createUser, token, err := c.useCase.AuthUser(context.Request.Context(), dtoUser)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err, nil, context, http.StatusBadRequest)
+		context.Error(err)
 		return
 	}
 	userResponses := &userResponse{
@@ -85,11 +100,10 @@ func (c *Controller) GetUserBookings(context *gin.Context) {
 		response(false, nil, "User uuid is missing", "My be jwt token missing?", context, http.StatusBadRequest)
 		return
 	}
-	bookings, err := c.useCase.GetUserReservations(userUUid.(string))
-	c.logger.Debug("bookings", bookings)
+	bookings, err := c.useCase.GetUserReservations(context.Request.Context(), userUUid.(string))
+	c.logger.Debug("Bookings", "bookings", bookings)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err, nil, context, http.StatusBadRequest)
+		context.Error(err)
 		return
 	}
 	response(true, bookings, nil, nil, context, http.StatusOK)
@@ -113,80 +127,87 @@ func (c *Controller) UpdateBooking(context *gin.Context) {
 		response(false, nil, "reservation id is missing", nil, context, http.StatusBadRequest)
 		return
 	}
-	reservationDto, err := c.useCase.GetReservationForId(reservationID)
+	if status == "canceled" {
+		if _, err := c.useCase.CancelReservation(context.Request.Context(), userUUID.(string), reservationID); err != nil {
+			context.Error(err)
+			return
+		}
+		response(true, "Update reservation success", nil, nil, context, http.StatusOK)
+		return
+	}
+	reservationDto, err := c.useCase.GetReservationForId(context.Request.Context(), reservationID)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		context.Error(err)
 		return
 	}
 	if userUUID.(string) != reservationDto.UserID {
-		c.logger.Warn("User uuid is unvalidated")
-		response(false, nil, "User uuid is unvalidated", "", context, http.StatusBadRequest)
+		context.Error(domain.ErrReservationForbidden)
 		return
 	}
-	if status == "canceled" {
-		updateReservation := dto.ReservationDTO{
-			ID:           reservationDto.ID,
-			UserID:       reservationDto.UserID,
-			RestaurantID: reservationDto.RestaurantID,
-			StartTime:    reservationDto.StartTime,
-			EndTime:      reservationDto.EndTime,
-			Status:       status,
-			Table:        reservationDto.Table,
-			Contacts: dto.ContactsDTO{
-				Name:  reservationDto.Contacts.Name,
-				Phone: reservationDto.Contacts.Phone,
-			},
-			Capacity: reservationDto.Capacity,
-		}
-		ok, err = c.useCase.UpdateReservation(updateReservation)
-		if err != nil {
-			c.logger.Error(err.Error())
-			response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
-			return
-		}
+	// Без If-Match изменение проверяется по версии, прочитанной выше, с If-Match — по версии клиента.
+	version := reservationDto.Version
+	if header := context.GetHeader(ifMatchHeader); header != "" {
+		matched, ok := parseIfMatch(header)
 		if !ok {
-			c.logger.Warn("Update reservation fail")
-			response(false, nil, "Update reservation fail", nil, context, http.StatusBadRequest)
+			response(false, nil, fieldError{Field: ifMatchHeader, Code: "format", Message: "must be a reservation ETag or *"}, nil, context, http.StatusBadRequest)
 			return
 		}
-		response(true, "Update reservation success", nil, nil, context, http.StatusOK)
-		return
+		if matched != 0 {
+			version = matched
+		}
 	}
 	var data updateReservationRequest
 	if err := context.ShouldBindJSON(&data); err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err, nil, context, http.StatusBadRequest)
+		c.logger.Warn("Invalid update reservation request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
 		return
 	}
 
-	c.logger.Debug("reservation", reservationDto)
+	c.logger.Debug("Reservation", "reservation", reservationDto)
+	// Сценарий берет из запроса только контакты и число гостей, остальное — из сохраненной брони.
 	updateReservation := dto.ReservationDTO{
-		ID:           reservationDto.ID,
-		UserID:       reservationDto.UserID,
-		RestaurantID: reservationDto.RestaurantID,
-		StartTime:    reservationDto.StartTime,
-		EndTime:      reservationDto.EndTime,
-		Status:       reservationDto.Status,
-		Table:        reservationDto.Table,
+		ID: reservationDto.ID,
 		Contacts: dto.ContactsDTO{
 			Name:  data.Contacts.Name,
 			Phone: data.Contacts.Phone,
 		},
 		Capacity: data.Capacity,
+		Version:  version,
 	}
-	ok, err = c.useCase.UpdateReservation(updateReservation)
+	updated, err := c.useCase.UpdateReservation(context.Request.Context(), updateReservation)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		context.Error(err)
 		return
 	}
+	context.Header(etagHeader, reservationETag(updated.Version))
+	response(true, "Update reservation success", nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) RescheduleBooking(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
 	if !ok {
-		c.logger.Warn("Update reservation fail")
-		response(false, nil, "Update reservation fail", nil, context, http.StatusBadRequest)
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
 		return
 	}
-	response(true, "Update reservation success", nil, nil, context, http.StatusOK)
+	var data rescheduleRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid reschedule request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	reservation, err := c.useCase.RescheduleReservation(context.Request.Context(), userUUID.(string), dto.RescheduleDTO{
+		ReservationID: context.Param("id"),
+		StartTime:     data.DateStart,
+		EndTime:       data.DateEnd,
+		Table:         data.Table,
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusOK)
 }
 
 func (c *Controller) CreateBooking(context *gin.Context) {
@@ -198,8 +219,8 @@ func (c *Controller) CreateBooking(context *gin.Context) {
 		return
 	}
 	if err := context.ShouldBind(&data); err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err, nil, context, http.StatusBadRequest)
+		c.logger.Warn("Invalid create reservation request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
 		return
 	}
 	userUUid, ok := context.Get("userUuid")
@@ -229,16 +250,106 @@ func (c *Controller) CreateBooking(context *gin.Context) {
 			Phone: data.Contacts.Phone,
 		},
 	}
-	c.logger.Debug("lenTable controller %v", len(reservationDto.Table))
-	createBooking, err := c.useCase.CreateReservation(reservationDto)
+	c.logger.Debug("Create booking", "tables", len(reservationDto.Table))
+	if key := context.GetHeader(idempotencyKeyHeader); key != "" {
+		if len(key) > maxIdempotencyKeyLength {
+			c.logger.Warn("Idempotency key is too long")
+			response(false, nil, "Idempotency-Key must not exceed 255 characters", nil, context, http.StatusBadRequest)
+			return
+		}
+		createBooking, replayed, err := c.useCase.CreateReservationIdempotent(context.Request.Context(), key, reservationDto)
+		if err != nil {
+			context.Error(err)
+			return
+		}
+		if replayed {
+			context.Header(idempotentReplayedHeader, "true")
+		}
+		response(true, createBooking, nil, nil, context, http.StatusOK)
+		return
+	}
+	createBooking, err := c.useCase.CreateReservation(context.Request.Context(), reservationDto)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		context.Error(err)
 		return
 	}
 	response(true, createBooking, nil, nil, context, http.StatusOK)
 }
 
+func (c *Controller) CreateBookingSeries(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data reservationSeriesRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid reservation series request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	tablesDto := make([]dto.TableDTO, 0, len(data.Table))
+	for _, tableID := range data.Table {
+		tablesDto = append(tablesDto, dto.TableDTO{ID: tableID})
+	}
+	reservationDto := dto.ReservationDTO{
+		UserID:       userUUID.(string),
+		RestaurantID: restaurantId,
+		StartTime:    data.DateStart,
+		EndTime:      data.DateEnd,
+		Status:       "wait",
+		Table:        tablesDto,
+		Capacity:     data.Capacity,
+		Contacts: dto.ContactsDTO{
+			Name:  data.Contacts.Name,
+			Phone: data.Contacts.Phone,
+		},
+	}
+	series, err := c.useCase.CreateReservationSeries(context.Request.Context(), reservationDto, dto.RecurrenceDTO{
+		Frequency: data.Recurrence.Frequency,
+		Until:     data.Recurrence.Until,
+		Count:     data.Recurrence.Count,
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, series, nil, nil, context, http.StatusCreated)
+}
+
+func (c *Controller) GetBookingSeries(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	series, err := c.useCase.GetReservationSeries(context.Request.Context(), userUUID.(string), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, series, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) CancelBookingSeries(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	series, err := c.useCase.CancelReservationSeries(context.Request.Context(), userUUID.(string), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, series, nil, nil, context, http.StatusOK)
+}
+
 func (c *Controller) GetBookingsDate(context *gin.Context) {
 	date := context.Param("date")
 	restaurantId := context.Param("restaurantId")
@@ -252,16 +363,16 @@ func (c *Controller) GetBookingsDate(context *gin.Context) {
 		response(false, nil, "date is missing", "", context, http.StatusBadRequest)
 		return
 	}
+	// Дата передается без смещения и трактуется как местное время ресторана.
 	dateTime, err := time.Parse("2006-01-02T15:04:05", date)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		c.logger.Warn("Invalid date", "date", date, "error", err)
+		response(false, nil, fieldError{Field: "date", Code: "format", Message: "must be in format 2006-01-02T15:04:05"}, nil, context, http.StatusBadRequest)
 		return
 	}
-	avaibleTable, err := c.useCase.GetTableForReservationDate(dateTime, restaurantId)
+	avaibleTable, err := c.useCase.GetTableForReservationDate(context.Request.Context(), dateTime, restaurantId)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		context.Error(err)
 		return
 	}
 	responseDto := make([]responseTableAvaible, 0, len(avaibleTable))
@@ -272,6 +383,7 @@ func (c *Controller) GetBookingsDate(context *gin.Context) {
 			TableNumber:  table.TableNumber,
 			Capacity:     table.Capacity,
 			IsAvaible:    table.IsAvaible,
+			IsOccupied:   table.IsOccupied,
 			PositionZ:    table.PositionZ,
 			PositionY:    table.PositionY,
 			PositionX:    table.PositionX,
@@ -287,16 +399,62 @@ func (c *Controller) GetUserBookingsDate(context *gin.Context) {
 	userUUID, ok := context.Get("userUuid")
 	if !ok {
 		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
 	}
 	dateTime, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		c.logger.Warn("Invalid date", "date", date, "error", err)
+		response(false, nil, fieldError{Field: "date", Code: "format", Message: "must be in format 2006-01-02"}, nil, context, http.StatusBadRequest)
+		return
 	}
-	userBookings, err := c.useCase.GetUserReservationsDate(&dateTime, userUUID.(string))
+	userBookings, err := c.useCase.GetUserReservationsDate(context.Request.Context(), &dateTime, userUUID.(string))
 	if err != nil {
-		c.logger.Error(err.Error())
-		response(false, nil, err.Error(), nil, context, http.StatusBadRequest)
+		context.Error(err)
+		return
 	}
 	response(true, userBookings, nil, nil, context, http.StatusOK)
 }
+
+// GetBooking возвращает бронь ее владельцу вместе с ETag для последующего If-Match.
+func (c *Controller) GetBooking(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservation, err := c.useCase.GetReservationForId(context.Request.Context(), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if userUUID.(string) != reservation.UserID {
+		context.Error(domain.ErrReservationForbidden)
+		return
+	}
+	context.Header(etagHeader, reservationETag(reservation.Version))
+	response(true, reservation, nil, nil, context, http.StatusOK)
+}
+
+func reservationETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch разбирает заголовок If-Match с одним ETag брони. "*" возвращает 0 —
+// подходит любая версия. Слабые ETag не принимаются: If-Match требует точного совпадения.
+func parseIfMatch(header string) (int64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, true
+	}
+	value, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// statusClientClosedRequest — клиент закрыл соединение до ответа (nginx 499).
+const statusClientClosedRequest = 499
+
+// HandleErrors — middleware, которое переводит ошибки, добавленные обработчиками
+// через gin.Context.Error, в HTTP-ответ со стабильным кодом ошибки.
+func (c *Controller) HandleErrors(context *gin.Context) {
+	context.Next()
+
+	if len(context.Errors) == 0 || context.Writer.Written() {
+		return
+	}
+	err := context.Errors.Last().Err
+	status, body := c.mapError(err)
+	if status >= http.StatusInternalServerError {
+		c.logger.Error("Request failed", "path", context.FullPath(), "error", err)
+	} else {
+		c.logger.Warn("Request rejected", "path", context.FullPath(), "error", err)
+	}
+	response(false, nil, body, nil, context, status)
+}
+
+func (c *Controller) mapError(err error) (int, fieldError) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return kindStatus(domainErr.Kind), fieldError{Code: domainErr.Code, Message: domainErr.Message}
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, fieldError{Code: "timeout", Message: "request timed out"}
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest, fieldError{Code: "canceled", Message: "request canceled"}
+	default:
+		return http.StatusInternalServerError, fieldError{Code: "internal", Message: "internal server error"}
+	}
+}
+
+func kindStatus(kind domain.ErrorKind) int {
+	switch kind {
+	case domain.KindNotFound:
+		return http.StatusNotFound
+	case domain.KindConflict:
+		return http.StatusConflict
+	case domain.KindValidation:
+		return http.StatusUnprocessableEntity
+	case domain.KindForbidden:
+		return http.StatusForbidden
+	case domain.KindRateLimit:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+// maxNotificationSize — предельный размер тела уведомления платежного провайдера.
+const maxNotificationSize = 64 << 10
+
+// PaymentWebhook принимает уведомления платежного провайдера. Аутентификации нет:
+// провайдер проверяет подлинность уведомления сам, перезапрашивая платеж.
+func (c *Controller) PaymentWebhook(context *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(context.Writer, context.Request.Body, maxNotificationSize))
+	if err != nil {
+		c.logger.Warn("Failed to read payment notification", "error", err)
+		response(false, nil, "Failed to read payment notification", nil, context, http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err = c.useCase.HandlePaymentNotification(context.Request.Context(), body); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, nil, nil, nil, context, http.StatusOK)
+}
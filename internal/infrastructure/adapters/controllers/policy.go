@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (c *Controller) GetBookingPolicy(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	policy, err := c.useCase.GetBookingPolicy(context.Request.Context(), restaurantId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, policy, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) UpdateBookingPolicy(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data bookingPolicyRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid booking policy request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	policyDto := dto.BookingPolicyDTO{
+		RestaurantID:              restaurantId,
+		MinDurationMinutes:        data.MinDurationMinutes,
+		MaxDurationMinutes:        data.MaxDurationMinutes,
+		SlotGranularityMinutes:    data.SlotGranularityMinutes,
+		LeadTimeMinutes:           data.LeadTimeMinutes,
+		BookingHorizonMinutes:     data.BookingHorizonMinutes,
+		MaxTablesPerBooking:       data.MaxTablesPerBooking,
+		MaxPartySize:              data.MaxPartySize,
+		TurnoverBufferMinutes:     data.TurnoverBufferMinutes,
+		EventMinDurationMinutes:   data.EventMinDurationMinutes,
+		EventMaxDurationMinutes:   data.EventMaxDurationMinutes,
+		DepositPartySize:          data.DepositPartySize,
+		DepositPerGuest:           data.DepositPerGuest,
+		PeakStartMinute:           data.PeakStartMinute,
+		PeakEndMinute:             data.PeakEndMinute,
+		PaymentHoldMinutes:        data.PaymentHoldMinutes,
+		RefundWindowMinutes:       data.RefundWindowMinutes,
+		CancellationCutoffMinutes: data.CancellationCutoffMinutes,
+		MaxActiveBookings:         data.MaxActiveBookings,
+		ApprovalHoldMinutes:       data.ApprovalHoldMinutes,
+	}
+	policy, err := c.useCase.UpdateBookingPolicy(context.Request.Context(), userUUID.(string), policyDto)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, policy, nil, nil, context, http.StatusOK)
+}
@@ -3,19 +3,135 @@ package controllers
 import "time"
 
 type reservationRequest struct {
-	DateStart time.Time       `json:"date_start"`
-	DateEnd   time.Time       `json:"date_end"`
-	Capacity  int             `json:"capacity"`
-	Contacts  contactsRequest `json:"contacts"`
-	Table     []string
+	DateStart time.Time       `json:"date_start" binding:"required"`
+	DateEnd   time.Time       `json:"date_end" binding:"required,gtfield=DateStart"`
+	Capacity  int             `json:"capacity" binding:"gt=0"`
+	Contacts  contactsRequest `json:"contacts" binding:"required"`
+	Table     []string        `json:"table" binding:"required,min=1,unique,dive,required"`
+}
+
+type reservationSeriesRequest struct {
+	DateStart  time.Time         `json:"date_start" binding:"required"`
+	DateEnd    time.Time         `json:"date_end" binding:"required,gtfield=DateStart"`
+	Capacity   int               `json:"capacity" binding:"gt=0"`
+	Contacts   contactsRequest   `json:"contacts" binding:"required"`
+	Table      []string          `json:"table" binding:"required,min=1,unique,dive,required"`
+	Recurrence recurrenceRequest `json:"recurrence" binding:"required"`
+}
+
+type recurrenceRequest struct {
+	Frequency string     `json:"frequency" binding:"required,oneof=weekly biweekly monthly"`
+	Until     *time.Time `json:"until" binding:"required_without=Count,excluded_with=Count"`
+	Count     int        `json:"count" binding:"required_without=Until,gte=0,max=52"`
 }
 
 type contactsRequest struct {
-	Name  string `json:"name"`
-	Phone string `json:"phone"`
+	Name  string `json:"name" binding:"required,max=255"`
+	Phone string `json:"phone" binding:"required,phone"`
 }
 
 type updateReservationRequest struct {
-	Contacts contactsRequest `json:"contacts"`
-	Capacity int             `json:"capacity"`
+	Contacts contactsRequest `json:"contacts" binding:"required"`
+	Capacity int             `json:"capacity" binding:"gt=0"`
+}
+
+type rescheduleRequest struct {
+	DateStart time.Time `json:"date_start" binding:"required"`
+	DateEnd   time.Time `json:"date_end" binding:"required,gtfield=DateStart"`
+	Table     []string  `json:"table" binding:"omitempty,unique,dive,required"`
+}
+
+type bookingPolicyRequest struct {
+	MinDurationMinutes        int   `json:"min_duration_minutes" binding:"gte=0"`
+	MaxDurationMinutes        int   `json:"max_duration_minutes" binding:"gte=0"`
+	SlotGranularityMinutes    int   `json:"slot_granularity_minutes" binding:"gte=0,max=1440"`
+	LeadTimeMinutes           int   `json:"lead_time_minutes" binding:"gte=0"`
+	BookingHorizonMinutes     int   `json:"booking_horizon_minutes" binding:"gte=0"`
+	MaxTablesPerBooking       int   `json:"max_tables_per_booking" binding:"gte=0"`
+	MaxPartySize              int   `json:"max_party_size" binding:"gte=0"`
+	TurnoverBufferMinutes     int   `json:"turnover_buffer_minutes" binding:"gte=0"`
+	EventMinDurationMinutes   int   `json:"event_min_duration_minutes" binding:"gte=0"`
+	EventMaxDurationMinutes   int   `json:"event_max_duration_minutes" binding:"gte=0"`
+	DepositPartySize          int   `json:"deposit_party_size" binding:"gte=0"`
+	DepositPerGuest           int64 `json:"deposit_per_guest" binding:"gte=0"`
+	PeakStartMinute           int   `json:"peak_start_minute" binding:"min=0,max=1439"`
+	PeakEndMinute             int   `json:"peak_end_minute" binding:"min=0,max=1439"`
+	PaymentHoldMinutes        int   `json:"payment_hold_minutes" binding:"gte=0"`
+	RefundWindowMinutes       int   `json:"refund_window_minutes" binding:"gte=0"`
+	CancellationCutoffMinutes int   `json:"cancellation_cutoff_minutes" binding:"gte=0"`
+	MaxActiveBookings         int   `json:"max_active_bookings" binding:"gte=0"`
+	ApprovalHoldMinutes       int   `json:"approval_hold_minutes" binding:"gte=0"`
+}
+
+type openingHoursRequest struct {
+	Hours []openingHoursItem `json:"hours" binding:"dive"`
+}
+
+type openingHoursItem struct {
+	Weekday     int `json:"weekday" binding:"min=0,max=6"`
+	OpenMinute  int `json:"open_minute" binding:"min=0,max=1439"`
+	CloseMinute int `json:"close_minute" binding:"min=0,max=1439"`
+}
+
+type specialDayRequest struct {
+	Closed      bool   `json:"closed"`
+	OpenMinute  int    `json:"open_minute" binding:"min=0,max=1439"`
+	CloseMinute int    `json:"close_minute" binding:"min=0,max=1439"`
+	Note        string `json:"note" binding:"max=255"`
+}
+
+type closureRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	Reason    string    `json:"reason" binding:"max=255"`
+}
+
+type tableBlockRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	Reason    string    `json:"reason" binding:"required,max=255"`
+}
+
+type waitlistRequest struct {
+	DateStart time.Time       `json:"date_start" binding:"required"`
+	DateEnd   time.Time       `json:"date_end" binding:"required,gtfield=DateStart"`
+	Capacity  int             `json:"capacity" binding:"gt=0"`
+	Contacts  contactsRequest `json:"contacts" binding:"required"`
+}
+
+type walkInRequest struct {
+	Table           []string        `json:"table" binding:"required,min=1,unique,dive,required"`
+	Capacity        int             `json:"capacity" binding:"gt=0"`
+	DurationMinutes int             `json:"duration_minutes" binding:"gte=0,max=1440"`
+	Contacts        *walkInContacts `json:"contacts"`
+}
+
+type walkInContacts struct {
+	Name  string `json:"name" binding:"max=255"`
+	Phone string `json:"phone" binding:"omitempty,phone"`
+}
+
+type zoneRequest struct {
+	Name               string   `json:"name" binding:"required,max=255"`
+	Tables             []string `json:"tables" binding:"required,min=1,unique,dive,required"`
+	MinDurationMinutes int      `json:"min_duration_minutes" binding:"gte=0"`
+	MaxDurationMinutes int      `json:"max_duration_minutes" binding:"gte=0"`
+	RequiresApproval   bool     `json:"requires_approval"`
+}
+
+type eventBookingRequest struct {
+	DateStart time.Time       `json:"date_start" binding:"required"`
+	DateEnd   time.Time       `json:"date_end" binding:"required,gtfield=DateStart"`
+	Capacity  int             `json:"capacity" binding:"gt=0"`
+	Contacts  contactsRequest `json:"contacts" binding:"required"`
+}
+
+type auditLogRequest struct {
+	EntityType string    `form:"entity_type" json:"entity_type" binding:"omitempty,oneof=reservation table restaurant"`
+	EntityID   string    `form:"entity_id" json:"entity_id"`
+	ActorID    string    `form:"actor_id" json:"actor_id"`
+	From       time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00" binding:"omitempty,gtfield=From"`
+	Limit      int       `form:"limit" json:"limit" binding:"gte=0,max=500"`
+	Offset     int       `form:"offset" json:"offset" binding:"gte=0"`
 }
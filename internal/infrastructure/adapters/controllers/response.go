@@ -1,6 +1,10 @@
 package controllers
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
 
 type userResponse struct {
 	Name string `json:"name"`
@@ -16,6 +20,7 @@ type responseTableAvaible struct {
 	PositionY    float64 `json:"position_y"`
 	PositionZ    float64 `json:"position_z"`
 	IsAvaible    bool    `json:"is_available"`
+	IsOccupied   bool    `json:"is_occupied"`
 }
 
 type anyResponse struct {
@@ -25,6 +30,44 @@ type anyResponse struct {
 	Meta   *interface{} `json:"meta,omitempty"`
 }
 
+// errorList приводит ошибку ответа к единому виду — списку fieldError.
+func errorList(errs interface{}, httpStatusCode int) []fieldError {
+	switch e := errs.(type) {
+	case []fieldError:
+		return e
+	case fieldError:
+		return []fieldError{e}
+	case error:
+		return []fieldError{{Code: statusCode(httpStatusCode), Message: e.Error()}}
+	case string:
+		return []fieldError{{Code: statusCode(httpStatusCode), Message: e}}
+	default:
+		return []fieldError{{Code: statusCode(httpStatusCode), Message: fmt.Sprint(e)}}
+	}
+}
+
+// statusCode возвращает машинно-читаемый код для ошибок без собственного кода.
+func statusCode(httpStatusCode int) string {
+	switch httpStatusCode {
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusUnprocessableEntity:
+		return "validation_failed"
+	case http.StatusGatewayTimeout:
+		return "timeout"
+	case http.StatusInternalServerError:
+		return "internal"
+	default:
+		return "bad_request"
+	}
+}
+
 func response(status bool,
 	data interface{},
 	errors interface{},
@@ -47,7 +90,8 @@ func response(status bool,
 		response.Data = &data
 	}
 	if errors != nil {
-		response.Errors = &errors
+		var list interface{} = errorList(errors, httpStatusCode)
+		response.Errors = &list
 	}
 	if meta != nil {
 		response.Meta = &meta
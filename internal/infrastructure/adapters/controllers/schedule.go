@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// scheduleDefaultRange — период расписания, который отдается без явных from и to.
+const scheduleDefaultRange = 7 * 24 * time.Hour
+
+func (c *Controller) GetSchedule(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	from, to, ok := dateRange(context)
+	if !ok {
+		return
+	}
+
+	schedule, err := c.useCase.GetSchedule(context.Request.Context(), restaurantId, from, to)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, schedule, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) UpdateOpeningHours(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data openingHoursRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid opening hours request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	hours := make([]dto.OpeningHoursDTO, 0, len(data.Hours))
+	for _, h := range data.Hours {
+		hours = append(hours, dto.OpeningHoursDTO{
+			Weekday:     h.Weekday,
+			OpenMinute:  h.OpenMinute,
+			CloseMinute: h.CloseMinute,
+		})
+	}
+	if err := c.useCase.UpdateOpeningHours(context.Request.Context(), userUUID.(string), restaurantId, hours); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, hours, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) SaveSpecialDay(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	date, err := time.Parse("2006-01-02", context.Param("date"))
+	if err != nil {
+		response(false, nil, fieldError{Field: "date", Code: "format", Message: "must be in format 2006-01-02"}, nil, context, http.StatusBadRequest)
+		return
+	}
+	var data specialDayRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid special day request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	day := dto.SpecialDayDTO{
+		RestaurantID: restaurantId,
+		Date:         date,
+		Closed:       data.Closed,
+		OpenMinute:   data.OpenMinute,
+		CloseMinute:  data.CloseMinute,
+		Note:         data.Note,
+	}
+	if err := c.useCase.SaveSpecialDay(context.Request.Context(), userUUID.(string), day); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, day, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) DeleteSpecialDay(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	date, err := time.Parse("2006-01-02", context.Param("date"))
+	if err != nil {
+		response(false, nil, fieldError{Field: "date", Code: "format", Message: "must be in format 2006-01-02"}, nil, context, http.StatusBadRequest)
+		return
+	}
+	if err := c.useCase.DeleteSpecialDay(context.Request.Context(), userUUID.(string), restaurantId, date); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, "Special day deleted", nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) CreateClosure(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data closureRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid closure request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	closure, err := c.useCase.CreateClosure(context.Request.Context(), userUUID.(string), dto.ClosureDTO{
+		RestaurantID: restaurantId,
+		StartTime:    data.StartTime,
+		EndTime:      data.EndTime,
+		Reason:       data.Reason,
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, closure, nil, nil, context, http.StatusCreated)
+}
+
+func (c *Controller) DeleteClosure(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	if err := c.useCase.DeleteClosure(context.Request.Context(), userUUID.(string), restaurantId, context.Param("closureId")); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, "Closure deleted", nil, nil, context, http.StatusOK)
+}
+
+// dateRange разбирает параметры from и to (включительно) в формате 2006-01-02.
+// Без параметров возвращает неделю, начиная с сегодняшнего дня. При ошибке сам пишет ответ.
+func dateRange(context *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if value := context.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			response(false, nil, fieldError{Field: "from", Code: "format", Message: "must be in format 2006-01-02"}, nil, context, http.StatusBadRequest)
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+	to := from.Add(scheduleDefaultRange)
+	if value := context.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			response(false, nil, fieldError{Field: "to", Code: "format", Message: "must be in format 2006-01-02"}, nil, context, http.StatusBadRequest)
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		response(false, nil, fieldError{Field: "to", Code: "gtfield", Message: "must be after from"}, nil, context, http.StatusUnprocessableEntity)
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (c *Controller) SeatWalkIn(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data walkInRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid walk-in request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	walkIn := dto.WalkInDTO{
+		RestaurantID:    restaurantId,
+		Table:           data.Table,
+		Capacity:        data.Capacity,
+		DurationMinutes: data.DurationMinutes,
+	}
+	if data.Contacts != nil {
+		walkIn.Contacts = dto.ContactsDTO{
+			Name:  data.Contacts.Name,
+			Phone: data.Contacts.Phone,
+		}
+	}
+	reservation, err := c.useCase.SeatWalkIn(context.Request.Context(), userUUID.(string), walkIn)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusCreated)
+}
+
+func (c *Controller) SeatReservation(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservation, err := c.useCase.SeatReservation(context.Request.Context(), userUUID.(string), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) MarkLeft(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservation, err := c.useCase.MarkLeft(context.Request.Context(), userUUID.(string), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) MarkNoShow(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservation, err := c.useCase.MarkNoShow(context.Request.Context(), userUUID.(string), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) GetGuestReliability(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reliability, err := c.useCase.GetGuestReliability(context.Request.Context(), userUUID.(string), context.Param("restaurantId"), context.Param("userId"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reliability, nil, nil, context, http.StatusOK)
+}
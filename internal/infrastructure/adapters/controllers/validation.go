@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// fieldError описывает ошибку в ответе API. Field пуст для ошибок, не относящихся к полю запроса.
+type fieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// phonePattern допускает номер в международном формате: необязательный "+" и 10–15 цифр.
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+
+var registerValidatorsOnce sync.Once
+
+// registerValidators подключает к валидатору gin пользовательские правила
+// и использует json-теги как имена полей в ошибках.
+func registerValidators() {
+	registerValidatorsOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+		if err := v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+			return phonePattern.MatchString(fl.Field().String())
+		}); err != nil {
+			panic(err)
+		}
+	})
+}
+
+// bindingErrors переводит ошибку ShouldBind в список ошибок по полям.
+func bindingErrors(err error) []fieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		result := make([]fieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			result = append(result, fieldError{
+				Field:   fieldPath(fe.Namespace()),
+				Code:    fe.Tag(),
+				Message: validationMessage(fe),
+			})
+		}
+		return result
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []fieldError{{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type),
+		}}
+	}
+
+	return []fieldError{{Code: "malformed", Message: err.Error()}}
+}
+
+// bindingStatus возвращает 422 для нарушенных правил валидации и 400 для неразборчивого тела запроса.
+func bindingStatus(err error) int {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+// fieldPath убирает из пути поля имя корневой структуры запроса.
+func fieldPath(namespace string) string {
+	_, path, ok := strings.Cut(namespace, ".")
+	if !ok {
+		return namespace
+	}
+	return path
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be greater than or equal to " + fe.Param()
+	case "min":
+		if fe.Kind() == reflect.Slice {
+			return "must contain at least " + fe.Param() + " items"
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return "must be at most " + fe.Param() + " characters long"
+		}
+		return "must be at most " + fe.Param()
+	case "gtfield":
+		return "must be after " + snakeCase(fe.Param())
+	case "unique":
+		return "must not contain duplicates"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "required_without":
+		return "is required when " + snakeCase(fe.Param()) + " is not set"
+	case "excluded_with":
+		return "must not be set together with " + snakeCase(fe.Param())
+	case "phone":
+		return "must be a phone number in international format, e.g. +79991234567"
+	default:
+		return "failed on the '" + fe.Tag() + "' rule"
+	}
+}
+
+// snakeCase переводит имя поля Go в имя поля JSON: DateStart -> date_start.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (c *Controller) JoinWaitlist(context *gin.Context) {
+	restaurantId := context.Param("restaurantId")
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data waitlistRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid waitlist request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	entry, err := c.useCase.JoinWaitlist(context.Request.Context(), dto.WaitlistEntryDTO{
+		UserID:       userUUID.(string),
+		RestaurantID: restaurantId,
+		StartTime:    data.DateStart,
+		EndTime:      data.DateEnd,
+		Capacity:     data.Capacity,
+		Contacts: dto.ContactsDTO{
+			Name:  data.Contacts.Name,
+			Phone: data.Contacts.Phone,
+		},
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, entry, nil, nil, context, http.StatusCreated)
+}
+
+func (c *Controller) GetUserWaitlist(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	entries, err := c.useCase.GetUserWaitlist(context.Request.Context(), userUUID.(string))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, entries, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) LeaveWaitlist(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	if err := c.useCase.LeaveWaitlist(context.Request.Context(), userUUID.(string), context.Param("id")); err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, "Waitlist entry canceled", nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) ClaimWaitlistOffer(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservation, err := c.useCase.ClaimWaitlistOffer(context.Request.Context(), userUUID.(string), context.Param("id"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusOK)
+}
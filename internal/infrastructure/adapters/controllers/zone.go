@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"booking_system/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (c *Controller) GetZones(context *gin.Context) {
+	zones, err := c.useCase.GetZones(context.Request.Context(), context.Param("restaurantId"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, zones, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) CreateZone(context *gin.Context) {
+	c.saveZone(context, "", http.StatusCreated)
+}
+
+func (c *Controller) UpdateZone(context *gin.Context) {
+	c.saveZone(context, context.Param("zoneId"), http.StatusOK)
+}
+
+func (c *Controller) saveZone(context *gin.Context, zoneId string, status int) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data zoneRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid zone request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	zone, err := c.useCase.SaveZone(context.Request.Context(), userUUID.(string), dto.ZoneDTO{
+		ID:                 zoneId,
+		RestaurantID:       context.Param("restaurantId"),
+		Name:               data.Name,
+		TableIDs:           data.Tables,
+		MinDurationMinutes: data.MinDurationMinutes,
+		MaxDurationMinutes: data.MaxDurationMinutes,
+		RequiresApproval:   data.RequiresApproval,
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, zone, nil, nil, context, status)
+}
+
+func (c *Controller) DeleteZone(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	err := c.useCase.DeleteZone(context.Request.Context(), userUUID.(string), context.Param("restaurantId"), context.Param("zoneId"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, "Zone deleted", nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) BookZone(context *gin.Context) {
+	c.bookEvent(context, context.Param("zoneId"))
+}
+
+func (c *Controller) BookBuyout(context *gin.Context) {
+	c.bookEvent(context, "")
+}
+
+func (c *Controller) bookEvent(context *gin.Context, zoneId string) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	var data eventBookingRequest
+	if err := context.ShouldBindJSON(&data); err != nil {
+		c.logger.Warn("Invalid event booking request", "error", err)
+		response(false, nil, bindingErrors(err), nil, context, bindingStatus(err))
+		return
+	}
+
+	reservation, err := c.useCase.BookEvent(context.Request.Context(), dto.EventBookingDTO{
+		UserID:       userUUID.(string),
+		RestaurantID: context.Param("restaurantId"),
+		ZoneID:       zoneId,
+		StartTime:    data.DateStart,
+		EndTime:      data.DateEnd,
+		Capacity:     data.Capacity,
+		Contacts: dto.ContactsDTO{
+			Name:  data.Contacts.Name,
+			Phone: data.Contacts.Phone,
+		},
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusCreated)
+}
+
+func (c *Controller) GetPendingEvents(context *gin.Context) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservations, err := c.useCase.GetPendingEvents(context.Request.Context(), userUUID.(string), context.Param("restaurantId"))
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservations, nil, nil, context, http.StatusOK)
+}
+
+func (c *Controller) ApproveEvent(context *gin.Context) {
+	c.decideEvent(context, true)
+}
+
+func (c *Controller) RejectEvent(context *gin.Context) {
+	c.decideEvent(context, false)
+}
+
+func (c *Controller) decideEvent(context *gin.Context, approve bool) {
+	userUUID, ok := context.Get("userUuid")
+	if !ok {
+		c.logger.Warn("User uuid is missing")
+		response(false, nil, "User uuid is missing", nil, context, http.StatusBadRequest)
+		return
+	}
+	reservation, err := c.useCase.DecideEvent(context.Request.Context(), userUUID.(string), context.Param("id"), approve)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	response(true, reservation, nil, nil, context, http.StatusOK)
+}
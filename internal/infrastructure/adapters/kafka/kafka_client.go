@@ -1,4 +1,83 @@
 package kafka
 
+import (
+	"context"
+	"encoding/json"
+	"github.com/segmentio/kafka-go"
+	"log/slog"
+)
+
+// Client — асинхронный продюсер событий сервиса бронирования.
 type Client struct {
+	logger  *slog.Logger
+	writer  *kafka.Writer
+	brokers []string
+	dialer  *kafka.Dialer
+}
+
+func New(logger *slog.Logger, brokers []string, topic string, clientID string) *Client {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		Async:                  true,
+		AllowAutoTopicCreation: true,
+		Transport: &kafka.Transport{
+			ClientID: clientID,
+		},
+		Completion: func(messages []kafka.Message, err error) {
+			if err != nil {
+				logger.Error("Failed to deliver kafka messages", "count", len(messages), "error", err)
+			}
+		},
+	}
+	return &Client{
+		logger:  logger,
+		writer:  writer,
+		brokers: brokers,
+		dialer: &kafka.Dialer{
+			ClientID: clientID,
+		},
+	}
+}
+
+// Publish ставит событие в очередь на отправку. Ключ определяет партицию,
+// поэтому события одной сущности сохраняют порядок.
+func (c *Client) Publish(ctx context.Context, key string, event interface{}) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return c.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(key),
+		Value: value,
+	})
+}
+
+// Ping проверяет, что хотя бы один из брокеров принимает соединения.
+func (c *Client) Ping(ctx context.Context) error {
+	var lastErr error
+	for _, broker := range c.brokers {
+		conn, err := c.dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn.Close()
+	}
+	return lastErr
+}
+
+// Close дожидается отправки накопленных сообщений и закрывает соединения.
+func (c *Client) Close(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.writer.Close()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
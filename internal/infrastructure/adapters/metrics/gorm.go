@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+const gormStartedKey = "metrics:started_at"
+
+// gormPlugin замеряет время выполнения запросов GORM.
+type gormPlugin struct {
+	metrics *Metrics
+}
+
+// GormPlugin возвращает плагин для подключения через gorm.DB.Use.
+func (m *Metrics) GormPlugin() gorm.Plugin {
+	return gormPlugin{metrics: m}
+}
+
+func (p gormPlugin) Name() string {
+	return "booking:metrics"
+}
+
+func (p gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (p gormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(gormStartedKey, time.Now())
+}
+
+func (p gormPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartedKey)
+		if !ok {
+			return
+		}
+		started, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		status := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			status = "error"
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		p.metrics.dbDuration.WithLabelValues(operation, table, status).Observe(time.Since(started).Seconds())
+	}
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"time"
+)
+
+// Middleware измеряет длительность запросов. В метку route попадает шаблон
+// роута, а не фактический путь, чтобы id в URL не раздували кардинальность.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		m.httpDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(started).Seconds())
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "booking"
+
+// Metrics хранит реестр Prometheus и все метрики сервиса.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpDuration *prometheus.HistogramVec
+	dbDuration   *prometheus.HistogramVec
+
+	reservationsCreated  *prometheus.CounterVec
+	reservationsCanceled *prometheus.CounterVec
+	reservationsFailed   *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Длительность обработки HTTP-запросов по роутам и кодам ответа.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "query_duration_seconds",
+			Help:      "Длительность запросов к базе данных по операциям и таблицам.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table", "status"}),
+		reservationsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_created_total",
+			Help:      "Количество созданных бронирований.",
+		}, []string{"restaurant_id"}),
+		reservationsCanceled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_canceled_total",
+			Help:      "Количество отмененных бронирований.",
+		}, []string{"restaurant_id"}),
+		reservationsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_failed_total",
+			Help:      "Количество неудачных попыток бронирования по причинам.",
+		}, []string{"restaurant_id", "reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.dbDuration,
+		m.reservationsCreated,
+		m.reservationsCanceled,
+		m.reservationsFailed,
+	)
+	return m
+}
+
+// Handler отдает метрики в формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ReservationCreated(restaurantID string) {
+	m.reservationsCreated.WithLabelValues(restaurantID).Inc()
+}
+
+func (m *Metrics) ReservationCanceled(restaurantID string) {
+	m.reservationsCanceled.WithLabelValues(restaurantID).Inc()
+}
+
+func (m *Metrics) ReservationFailed(restaurantID string, reason string) {
+	m.reservationsFailed.WithLabelValues(restaurantID, reason).Inc()
+}
@@ -0,0 +1,108 @@
+package payments
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Статусы уведомлений поддельного провайдера, повторяют ЮKassa.
+const (
+	FakeSucceeded = "succeeded"
+	FakeCanceled  = "canceled"
+)
+
+// FakeNotification — тело уведомления поддельного провайдера.
+type FakeNotification struct {
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+}
+
+// Fake — провайдер в памяти для локального запуска и тестов. Платежи не уходят
+// наружу, а оплата имитируется отправкой FakeNotification на вебхук.
+type Fake struct {
+	currency  string
+	returnURL string
+
+	mu       sync.Mutex
+	payments map[string]domain.Payment // Ключ — ID платежа у провайдера
+	refunds  map[string]bool
+}
+
+func NewFake(currency string, returnURL string) *Fake {
+	return &Fake{
+		currency:  currency,
+		returnURL: returnURL,
+		payments:  make(map[string]domain.Payment),
+		refunds:   make(map[string]bool),
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Currency() string {
+	return f.currency
+}
+
+// CreatePayment регистрирует платеж. ID у провайдера совпадает с payment.ID,
+// поэтому повторный вызов возвращает тот же платеж.
+func (f *Fake) CreatePayment(_ context.Context, payment domain.Payment, _ string) (domain.PaymentIntent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment.ExternalID = "fake_" + payment.ID
+	f.payments[payment.ExternalID] = payment
+	return domain.PaymentIntent{
+		ExternalID:      payment.ExternalID,
+		ConfirmationURL: f.returnURL + "?payment_id=" + payment.ExternalID,
+	}, nil
+}
+
+func (f *Fake) Refund(_ context.Context, payment domain.Payment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.payments[payment.ExternalID]; !ok {
+		return domain.ErrPaymentNotFound.Withf("fake payment %s not found", payment.ExternalID)
+	}
+	f.refunds[payment.ExternalID] = true
+	return nil
+}
+
+// Refunded сообщает, был ли возвращен платеж с данным ID у провайдера.
+func (f *Fake) Refunded(externalID string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.refunds[externalID]
+}
+
+// ParseNotification принимает только уведомления о платежах, созданных этим провайдером.
+func (f *Fake) ParseNotification(_ context.Context, body []byte) (domain.PaymentNotification, error) {
+	var n FakeNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return domain.PaymentNotification{}, domain.ErrInvalidNotification.Withf("malformed body: %v", err)
+	}
+	f.mu.Lock()
+	_, known := f.payments[n.PaymentID]
+	f.mu.Unlock()
+	if !known {
+		return domain.PaymentNotification{}, domain.ErrInvalidNotification.Withf("unknown payment %s", n.PaymentID)
+	}
+	return domain.PaymentNotification{ExternalID: n.PaymentID, Status: notificationStatus(n.Status)}, nil
+}
+
+// notificationStatus переводит статус платежа ЮKassa в статус платежа сервиса.
+// Промежуточные статусы возвращаются как есть и пропускаются сервисом.
+func notificationStatus(status string) string {
+	switch status {
+	case FakeSucceeded:
+		return domain.PaymentPaid
+	case FakeCanceled:
+		return domain.PaymentFailed
+	}
+	return status
+}
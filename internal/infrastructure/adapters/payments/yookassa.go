@@ -0,0 +1,156 @@
+package payments
+
+import (
+	"booking_system/internal/domain"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const yookassaAPI = "https://api.yookassa.ru/v3"
+
+// YooKassa — провайдер платежей ЮKassa (API v3).
+type YooKassa struct {
+	shopID    string
+	secretKey string
+	currency  string
+	returnURL string
+	baseURL   string
+	client    *http.Client
+}
+
+func NewYooKassa(shopID string, secretKey string, currency string, returnURL string) *YooKassa {
+	return &YooKassa{
+		shopID:    shopID,
+		secretKey: secretKey,
+		currency:  currency,
+		returnURL: returnURL,
+		baseURL:   yookassaAPI,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type yookassaAmount struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type yookassaPayment struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Confirmation struct {
+		ConfirmationURL string `json:"confirmation_url"`
+	} `json:"confirmation"`
+}
+
+func (y *YooKassa) Name() string {
+	return "yookassa"
+}
+
+func (y *YooKassa) Currency() string {
+	return y.currency
+}
+
+func (y *YooKassa) CreatePayment(ctx context.Context, payment domain.Payment, description string) (domain.PaymentIntent, error) {
+	request := map[string]interface{}{
+		"amount":  amount(payment),
+		"capture": true,
+		"confirmation": map[string]string{
+			"type":       "redirect",
+			"return_url": y.returnURL,
+		},
+		"description": description,
+		"metadata": map[string]string{
+			"payment_id":     payment.ID,
+			"reservation_id": payment.ReservationID,
+		},
+	}
+	var created yookassaPayment
+	if err := y.do(ctx, http.MethodPost, "/payments", payment.ID, request, &created); err != nil {
+		return domain.PaymentIntent{}, err
+	}
+	return domain.PaymentIntent{
+		ExternalID:      created.ID,
+		ConfirmationURL: created.Confirmation.ConfirmationURL,
+	}, nil
+}
+
+func (y *YooKassa) Refund(ctx context.Context, payment domain.Payment) error {
+	request := map[string]interface{}{
+		"payment_id": payment.ExternalID,
+		"amount":     amount(payment),
+	}
+	return y.do(ctx, http.MethodPost, "/refunds", "refund-"+payment.ID, request, nil)
+}
+
+// ParseNotification не доверяет телу уведомления: ЮKassa его не подписывает,
+// поэтому статус платежа перезапрашивается через API.
+func (y *YooKassa) ParseNotification(ctx context.Context, body []byte) (domain.PaymentNotification, error) {
+	var notification struct {
+		Type   string `json:"type"`
+		Object struct {
+			ID string `json:"id"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return domain.PaymentNotification{}, domain.ErrInvalidNotification.Withf("malformed body: %v", err)
+	}
+	if notification.Type != "notification" || notification.Object.ID == "" {
+		return domain.PaymentNotification{}, domain.ErrInvalidNotification.Withf("not a payment notification")
+	}
+	var payment yookassaPayment
+	if err := y.do(ctx, http.MethodGet, "/payments/"+notification.Object.ID, "", nil, &payment); err != nil {
+		return domain.PaymentNotification{}, err
+	}
+	return domain.PaymentNotification{ExternalID: payment.ID, Status: notificationStatus(payment.Status)}, nil
+}
+
+// do выполняет запрос к API. Непустой idempotenceKey защищает POST-запросы от повторного выполнения.
+func (y *YooKassa) do(ctx context.Context, method string, path string, idempotenceKey string, request interface{}, response interface{}) error {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, y.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(y.shopID, y.secretKey)
+	req.Header.Set("Content-Type", "application/json")
+	if idempotenceKey != "" {
+		req.Header.Set("Idempotence-Key", idempotenceKey)
+	}
+
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return domain.ErrPaymentUnavailable.Withf("yookassa %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.ErrPaymentNotFound.Withf("yookassa %s %s: not found", method, path)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return domain.ErrPaymentUnavailable.Withf("yookassa %s %s: status %d: %s", method, path, resp.StatusCode, detail)
+	}
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// amount переводит сумму из копеек в десятичную строку, которую ожидает API.
+func amount(payment domain.Payment) yookassaAmount {
+	return yookassaAmount{
+		Value:    fmt.Sprintf("%d.%02d", payment.Amount/100, payment.Amount%100),
+		Currency: payment.Currency,
+	}
+}
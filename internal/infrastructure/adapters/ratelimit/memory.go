@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory хранит корзины токенов в памяти процесса. Подходит для одной реплики
+// сервиса: у каждой реплики свои корзины.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	domain.TokenBucket
+	fullAt time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]bucket), now: time.Now}
+}
+
+func (m *Memory) Allow(_ context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next, allowed, retryAfter := limit.Take(m.buckets[key].TokenBucket, m.now())
+	if allowed {
+		m.buckets[key] = bucket{TokenBucket: next, fullAt: limit.FullAt(next)}
+	}
+	return allowed, retryAfter, nil
+}
+
+// Cleanup удаляет заполнившиеся корзины, чтобы память не росла с числом клиентов.
+func (m *Memory) Cleanup(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for key, b := range m.buckets {
+		if !b.fullAt.After(now) {
+			delete(m.buckets, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"testing"
+	"time"
+)
+
+// clock — управляемые часы для корзин в памяти.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestMemory() (*Memory, *clock) {
+	c := &clock{now: time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)}
+	m := NewMemory()
+	m.now = c.Now
+	return m, c
+}
+
+func TestMemoryAllow(t *testing.T) {
+	limit := domain.RateLimit{Burst: 2, Period: time.Minute}
+	type step struct {
+		key       string
+		advance   time.Duration
+		wantOK    bool
+		wantRetry time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst then retry after",
+			steps: []step{
+				{key: "a", wantOK: true},
+				{key: "a", wantOK: true},
+				{key: "a", wantRetry: 30 * time.Second},
+			},
+		},
+		{
+			name: "refill after waiting",
+			steps: []step{
+				{key: "a", wantOK: true},
+				{key: "a", wantOK: true},
+				{key: "a", advance: 10 * time.Second, wantRetry: 20 * time.Second},
+				{key: "a", advance: 20 * time.Second, wantOK: true},
+				{key: "a", wantRetry: 30 * time.Second},
+			},
+		},
+		{
+			name: "keys are independent",
+			steps: []step{
+				{key: "a", wantOK: true},
+				{key: "a", wantOK: true},
+				{key: "b", wantOK: true},
+				{key: "a", wantRetry: 30 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, c := newTestMemory()
+			for i, s := range tt.steps {
+				c.now = c.now.Add(s.advance)
+				ok, retry, err := m.Allow(context.Background(), s.key, limit)
+				if err != nil {
+					t.Fatalf("step %d: Allow: %v", i, err)
+				}
+				if ok != s.wantOK || retry != s.wantRetry {
+					t.Errorf("step %d: allowed %v, retry after %s; want %v, %s", i, ok, retry, s.wantOK, s.wantRetry)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryCleanup(t *testing.T) {
+	limit := domain.RateLimit{Burst: 2, Period: time.Minute}
+	tests := []struct {
+		name    string
+		advance time.Duration
+		wantKey bool
+	}{
+		{name: "refilling bucket is kept", advance: 29 * time.Second, wantKey: true},
+		{name: "full bucket is dropped", advance: 30 * time.Second, wantKey: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, c := newTestMemory()
+			if ok, _, _ := m.Allow(context.Background(), "a", limit); !ok {
+				t.Fatal("first request rejected")
+			}
+			c.now = c.now.Add(tt.advance)
+			if err := m.Cleanup(context.Background()); err != nil {
+				t.Fatalf("Cleanup: %v", err)
+			}
+			if _, ok := m.buckets["a"]; ok != tt.wantKey {
+				t.Errorf("bucket kept = %v, want %v", ok, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package routers
+
+import (
+	"booking_system/internal/app/ports"
+	"booking_system/internal/domain"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"math"
+	"strconv"
+)
+
+// RateLimits задает ограничения частоты запросов. Default применяется ко всем роутам
+// с ограничением, PerRoute переопределяет его по ключу "METHOD /api/v1/path", как в Timeouts.
+// Без Limiter ограничения не действуют.
+type RateLimits struct {
+	Limiter  ports.IRateLimiter
+	Default  domain.RateLimit
+	PerRoute map[string]domain.RateLimit
+}
+
+// Middleware ограничивает частоту запросов к роуту для каждого пользователя, а запросы
+// без JWT — для каждого IP. Ставится после JwtMiddleware, иначе пользователь еще неизвестен.
+// Если хранилище корзин недоступно, запрос пропускается: это не повод останавливать бронирования.
+func (l RateLimits) Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		limit := l.Default
+		if override, ok := l.PerRoute[route]; ok {
+			limit = override
+		}
+		if l.Limiter == nil || !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		key := "ip:" + c.ClientIP()
+		if userUUID, ok := c.Get("userUuid"); ok {
+			key = "user:" + userUUID.(string)
+		}
+		allowed, retryAfter, err := l.Limiter.Allow(c.Request.Context(), key+" "+route, limit)
+		if err != nil {
+			logger.Error("Rate limiter failed", "route", route, "error", err)
+			c.Next()
+			return
+		}
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.Error(domain.ErrTooManyRequests)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"booking_system/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDHeader — заголовок с ID запроса. Если клиент или балансировщик его не прислали,
+// ID генерируется. Он возвращается в ответе и попадает в журнал аудита.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength отсекает слишком длинные ID, присланные клиентом.
+const maxRequestIDLength = 128
+
+// RequestID кладет ID запроса в контекст, который передается дальше в сценарии.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.New().String()
+		}
+		c.Header(requestIDHeader, requestID)
+		c.Request = c.Request.WithContext(domain.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
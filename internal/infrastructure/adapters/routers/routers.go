@@ -12,15 +12,17 @@ type Router struct {
 	controllers ports.IController
 }
 
-func New(server *gin.Engine, logger *slog.Logger, controllers ports.IController, jwt *middelware.Jwt) {
+func New(server *gin.Engine, logger *slog.Logger, controllers ports.IController, jwt *middelware.Jwt, timeouts Timeouts, limits RateLimits) {
 	rout := Router{
 		logger:      logger,
 		controllers: controllers,
 	}
-	r := server.Group("api/v1")
+	r := server.Group("api/v1", RequestID(), timeouts.Middleware(), controllers.HandleErrors)
+	// Ограничение частоты для роутов, которыми можно занять столики или перебирать авторизацию
+	limit := limits.Middleware(logger)
 
 	// Роуты, связанные с аутентификацией и пользователем
-	r.GET("/auth/telegram", rout.Auth)
+	r.GET("/auth/telegram", limit, rout.Auth)
 	r.PATCH("/me", rout.UpdateInfo)
 	r.GET("/me", rout.GetUser)
 	// Роуты, связанные с бронированиями пользователя
@@ -28,14 +30,70 @@ func New(server *gin.Engine, logger *slog.Logger, controllers ports.IController,
 	r.GET("/booking/me/:date", jwt.JwtMiddleware(), rout.GetUserBooking)
 
 	// Роуты для работы с конкретными бронированиями
-	r.GET("/booking/:id", rout.GetBooking)
+	r.GET("/booking/:id", jwt.JwtMiddleware(), rout.GetBooking)
 	r.PATCH("/booking/:id", jwt.JwtMiddleware(), rout.UpdateBooking)
 	r.PATCH("/booking/:id/:status", jwt.JwtMiddleware(), rout.UpdateStatus)
+	r.POST("/booking/:id/reschedule", jwt.JwtMiddleware(), limit, rout.RescheduleBooking)
 
 	// Роуты для работы с бронированиями в ресторанах
-	r.POST("/:restaurantId/booking", jwt.JwtMiddleware(), rout.CreateBooking)
+	r.POST("/:restaurantId/booking", jwt.JwtMiddleware(), limit, rout.CreateBooking)
 	r.GET("/:restaurantId/bookings/:date", rout.GetBookingDate)
 
+	// Роуты для повторяющихся бронирований
+	r.POST("/:restaurantId/booking/series", jwt.JwtMiddleware(), limit, rout.CreateBookingSeries)
+	r.GET("/series/:id", jwt.JwtMiddleware(), rout.GetBookingSeries)
+	r.DELETE("/series/:id", jwt.JwtMiddleware(), rout.CancelBookingSeries)
+
+	// Роуты для управления правилами бронирования ресторана
+	r.GET("/:restaurantId/policy", rout.GetBookingPolicy)
+	r.PUT("/:restaurantId/policy", jwt.JwtMiddleware(), rout.UpdateBookingPolicy)
+
+	// Роуты для управления расписанием ресторана
+	r.GET("/:restaurantId/schedule", rout.GetSchedule)
+	r.PUT("/:restaurantId/schedule/hours", jwt.JwtMiddleware(), rout.UpdateOpeningHours)
+	r.PUT("/:restaurantId/schedule/special-days/:date", jwt.JwtMiddleware(), rout.SaveSpecialDay)
+	r.DELETE("/:restaurantId/schedule/special-days/:date", jwt.JwtMiddleware(), rout.DeleteSpecialDay)
+	r.POST("/:restaurantId/schedule/closures", jwt.JwtMiddleware(), rout.CreateClosure)
+	r.DELETE("/:restaurantId/schedule/closures/:closureId", jwt.JwtMiddleware(), rout.DeleteClosure)
+
+	// Вывод столика из зала
+	r.DELETE("/:restaurantId/tables/:tableId", jwt.JwtMiddleware(), rout.DeleteTable)
+
+	// Роуты для блокировки столиков персоналом
+	r.GET("/:restaurantId/blocks", jwt.JwtMiddleware(), rout.GetTableBlocks)
+	r.POST("/:restaurantId/tables/:tableId/blocks", jwt.JwtMiddleware(), rout.CreateTableBlock)
+	r.DELETE("/:restaurantId/blocks/:blockId", jwt.JwtMiddleware(), rout.DeleteTableBlock)
+
+	// Роуты листа ожидания
+	r.POST("/:restaurantId/waitlist", jwt.JwtMiddleware(), limit, rout.JoinWaitlist)
+	r.GET("/waitlist/me", jwt.JwtMiddleware(), rout.GetUserWaitlist)
+	r.DELETE("/waitlist/:id", jwt.JwtMiddleware(), rout.LeaveWaitlist)
+	r.POST("/waitlist/:id/claim", jwt.JwtMiddleware(), rout.ClaimWaitlistOffer)
+
+	// Роуты зон, броней зон целиком и выкупа ресторана
+	r.GET("/:restaurantId/zones", rout.GetZones)
+	r.POST("/:restaurantId/zones", jwt.JwtMiddleware(), rout.CreateZone)
+	r.PUT("/:restaurantId/zones/:zoneId", jwt.JwtMiddleware(), rout.UpdateZone)
+	r.DELETE("/:restaurantId/zones/:zoneId", jwt.JwtMiddleware(), rout.DeleteZone)
+	r.POST("/:restaurantId/zones/:zoneId/booking", jwt.JwtMiddleware(), limit, rout.BookZone)
+	r.POST("/:restaurantId/buyout", jwt.JwtMiddleware(), limit, rout.BookBuyout)
+	r.GET("/:restaurantId/events/pending", jwt.JwtMiddleware(), rout.GetPendingEvents)
+	r.POST("/booking/:id/approve", jwt.JwtMiddleware(), rout.ApproveEvent)
+	r.POST("/booking/:id/reject", jwt.JwtMiddleware(), rout.RejectEvent)
+
+	// Роуты рассадки гостей персоналом
+	r.POST("/:restaurantId/walk-ins", jwt.JwtMiddleware(), rout.SeatWalkIn)
+	r.POST("/booking/:id/seat", jwt.JwtMiddleware(), rout.SeatReservation)
+	r.POST("/booking/:id/leave", jwt.JwtMiddleware(), rout.MarkLeft)
+	r.POST("/booking/:id/no-show", jwt.JwtMiddleware(), rout.MarkNoShow)
+	r.GET("/:restaurantId/guests/:userId/reliability", jwt.JwtMiddleware(), rout.GetGuestReliability)
+
+	// Журнал аудита ресторана
+	r.GET("/:restaurantId/audit", jwt.JwtMiddleware(), rout.GetAuditLog)
+
+	// Уведомления платежного провайдера о депозитах
+	r.POST("/payments/webhook", rout.PaymentWebhook)
+
 }
 
 func (r Router) UpdateStatus(c *gin.Context) {
@@ -47,7 +105,7 @@ func (r Router) Auth(c *gin.Context) {
 }
 
 func (r Router) GetBooking(c *gin.Context) {
-
+	r.controllers.GetBooking(c)
 }
 
 func (r Router) GetUserBooking(c *gin.Context) {
@@ -71,3 +129,147 @@ func (r Router) GetBookingDate(c *gin.Context) {
 func (r Router) UpdateBooking(c *gin.Context) {
 	r.controllers.UpdateBooking(c)
 }
+
+func (r Router) GetBookingPolicy(c *gin.Context) {
+	r.controllers.GetBookingPolicy(c)
+}
+
+func (r Router) UpdateBookingPolicy(c *gin.Context) {
+	r.controllers.UpdateBookingPolicy(c)
+}
+
+func (r Router) GetSchedule(c *gin.Context) {
+	r.controllers.GetSchedule(c)
+}
+
+func (r Router) UpdateOpeningHours(c *gin.Context) {
+	r.controllers.UpdateOpeningHours(c)
+}
+
+func (r Router) SaveSpecialDay(c *gin.Context) {
+	r.controllers.SaveSpecialDay(c)
+}
+
+func (r Router) DeleteSpecialDay(c *gin.Context) {
+	r.controllers.DeleteSpecialDay(c)
+}
+
+func (r Router) CreateClosure(c *gin.Context) {
+	r.controllers.CreateClosure(c)
+}
+
+func (r Router) DeleteClosure(c *gin.Context) {
+	r.controllers.DeleteClosure(c)
+}
+
+func (r Router) GetTableBlocks(c *gin.Context) {
+	r.controllers.GetTableBlocks(c)
+}
+
+func (r Router) CreateTableBlock(c *gin.Context) {
+	r.controllers.CreateTableBlock(c)
+}
+
+func (r Router) DeleteTableBlock(c *gin.Context) {
+	r.controllers.DeleteTableBlock(c)
+}
+
+func (r Router) DeleteTable(c *gin.Context) {
+	r.controllers.DeleteTable(c)
+}
+
+func (r Router) JoinWaitlist(c *gin.Context) {
+	r.controllers.JoinWaitlist(c)
+}
+
+func (r Router) GetUserWaitlist(c *gin.Context) {
+	r.controllers.GetUserWaitlist(c)
+}
+
+func (r Router) LeaveWaitlist(c *gin.Context) {
+	r.controllers.LeaveWaitlist(c)
+}
+
+func (r Router) ClaimWaitlistOffer(c *gin.Context) {
+	r.controllers.ClaimWaitlistOffer(c)
+}
+
+func (r Router) SeatWalkIn(c *gin.Context) {
+	r.controllers.SeatWalkIn(c)
+}
+
+func (r Router) SeatReservation(c *gin.Context) {
+	r.controllers.SeatReservation(c)
+}
+
+func (r Router) MarkLeft(c *gin.Context) {
+	r.controllers.MarkLeft(c)
+}
+
+func (r Router) RescheduleBooking(c *gin.Context) {
+	r.controllers.RescheduleBooking(c)
+}
+
+func (r Router) CreateBookingSeries(c *gin.Context) {
+	r.controllers.CreateBookingSeries(c)
+}
+
+func (r Router) GetBookingSeries(c *gin.Context) {
+	r.controllers.GetBookingSeries(c)
+}
+
+func (r Router) CancelBookingSeries(c *gin.Context) {
+	r.controllers.CancelBookingSeries(c)
+}
+
+func (r Router) GetZones(c *gin.Context) {
+	r.controllers.GetZones(c)
+}
+
+func (r Router) CreateZone(c *gin.Context) {
+	r.controllers.CreateZone(c)
+}
+
+func (r Router) UpdateZone(c *gin.Context) {
+	r.controllers.UpdateZone(c)
+}
+
+func (r Router) DeleteZone(c *gin.Context) {
+	r.controllers.DeleteZone(c)
+}
+
+func (r Router) BookZone(c *gin.Context) {
+	r.controllers.BookZone(c)
+}
+
+func (r Router) BookBuyout(c *gin.Context) {
+	r.controllers.BookBuyout(c)
+}
+
+func (r Router) GetPendingEvents(c *gin.Context) {
+	r.controllers.GetPendingEvents(c)
+}
+
+func (r Router) ApproveEvent(c *gin.Context) {
+	r.controllers.ApproveEvent(c)
+}
+
+func (r Router) RejectEvent(c *gin.Context) {
+	r.controllers.RejectEvent(c)
+}
+
+func (r Router) PaymentWebhook(c *gin.Context) {
+	r.controllers.PaymentWebhook(c)
+}
+
+func (r Router) MarkNoShow(c *gin.Context) {
+	r.controllers.MarkNoShow(c)
+}
+
+func (r Router) GetGuestReliability(c *gin.Context) {
+	r.controllers.GetGuestReliability(c)
+}
+
+func (r Router) GetAuditLog(c *gin.Context) {
+	r.controllers.GetAuditLog(c)
+}
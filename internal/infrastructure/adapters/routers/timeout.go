@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// Timeouts задает дедлайны обработки запросов. Default применяется ко всем роутам,
+// PerRoute переопределяет его для отдельных роутов по ключу "METHOD /api/v1/path",
+// где path — шаблон роута, например "POST /api/v1/:restaurantId/booking".
+type Timeouts struct {
+	Default  time.Duration
+	PerRoute map[string]time.Duration
+}
+
+// Middleware ограничивает время жизни контекста запроса. Контекст передается
+// дальше в сценарии и хранилище, поэтому по дедлайну прерываются и запросы к БД.
+func (t Timeouts) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := t.Default
+		if override, ok := t.PerRoute[c.Request.Method+" "+c.FullPath()]; ok {
+			timeout = override
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,148 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"sync"
+	"testing"
+)
+
+var (
+	testExporter *tracetest.InMemoryExporter
+	testProvider *sdktrace.TracerProvider
+	providerOnce sync.Once
+)
+
+type testTable struct {
+	ID   string
+	Name string
+}
+
+// recordSpans ставит глобальный провайдер с экспортером в памяти один раз на пакет:
+// tracer, полученный до установки провайдера, привязывается только к первому из них.
+func recordSpans(t *testing.T) {
+	t.Helper()
+	providerOnce.Do(func() {
+		testExporter = tracetest.NewInMemoryExporter()
+		testProvider = NewTracerProvider(testExporter, "booking_system_test", 1)
+	})
+	testExporter.Reset()
+}
+
+func recordedSpans(t *testing.T) tracetest.SpanStubs {
+	t.Helper()
+	if err := testProvider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("flush spans: %v", err)
+	}
+	return testExporter.GetSpans()
+}
+
+// openDryRun открывает GORM без подключения к базе: запросы только собираются в SQL,
+// но колбэки плагина вызываются как при настоящем выполнении.
+func openDryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost", PreferSimpleProtocol: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open gorm: %v", err)
+	}
+	if err := db.Use(GormPlugin()); err != nil {
+		t.Fatalf("use plugin: %v", err)
+	}
+	return db
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) string {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestGormPluginCreatesChildSpan(t *testing.T) {
+	recordSpans(t)
+	db := openDryRun(t)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "Storage.GetTable")
+	var rows []testTable
+	db.WithContext(ctx).Where("name = ?", "terrace").Find(&rows)
+	parent.End()
+
+	var query, storage tracetest.SpanStub
+	for _, span := range recordedSpans(t) {
+		switch span.Name {
+		case "gorm.query":
+			query = span
+		case "Storage.GetTable":
+			storage = span
+		}
+	}
+	if query.Name == "" {
+		t.Fatal("gorm.query span not recorded")
+	}
+	if query.Parent.SpanID() != storage.SpanContext.SpanID() {
+		t.Errorf("gorm span parent = %s, want %s", query.Parent.SpanID(), storage.SpanContext.SpanID())
+	}
+
+	tests := []struct {
+		key  attribute.Key
+		want string
+	}{
+		{"db.system", "postgresql"},
+		{"db.operation", "query"},
+		{"db.sql.table", "test_tables"},
+		{"db.statement", `SELECT * FROM "test_tables" WHERE name = $1`},
+	}
+	for _, tt := range tests {
+		if got := spanAttribute(query, tt.key); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if query.Status.Code != codes.Unset {
+		t.Errorf("status = %v, want unset", query.Status.Code)
+	}
+}
+
+func TestGormPluginRecordsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "query error", err: errors.New("connection reset"), want: codes.Error},
+		{name: "record not found", err: gorm.ErrRecordNotFound, want: codes.Unset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recordSpans(t)
+			db := openDryRun(t)
+			err := db.Callback().Query().After("gorm:query").Before("tracing:after_query").
+				Register("test:fail", func(db *gorm.DB) { _ = db.AddError(tt.err) })
+			if err != nil {
+				t.Fatalf("register callback: %v", err)
+			}
+
+			var row testTable
+			db.WithContext(context.Background()).First(&row)
+
+			spans := recordedSpans(t)
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+			if spans[0].Status.Code != tt.want {
+				t.Errorf("status = %v, want %v", spans[0].Status.Code, tt.want)
+			}
+		})
+	}
+}
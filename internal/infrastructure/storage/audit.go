@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+)
+
+// AppendAuditEntry добавляет запись в журнал аудита.
+func (s *Storage) AppendAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, span := tracer.Start(ctx, "Storage.AppendAuditEntry")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Create(models.ConvertAuditEntryToModel(entry)).Error
+}
+
+// GetAuditLog возвращает записи журнала аудита ресторана, начиная с самых новых.
+func (s *Storage) GetAuditLog(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetAuditLog")
+	defer span.End()
+
+	query := s.Database.WithContext(ctx).Where("restaurant_id = ?", filter.RestaurantID)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var dbEntries []models.AuditEntry
+	err := query.Order("created_at DESC, id").Limit(filter.Limit).Offset(filter.Offset).Find(&dbEntries).Error
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]domain.AuditEntry, 0, len(dbEntries))
+	for i := range dbEntries {
+		entries = append(entries, *models.ConvertAuditEntryToDomain(&dbEntries[i]))
+	}
+	return entries, nil
+}
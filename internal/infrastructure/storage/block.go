@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// CreateTableBlock сохраняет блокировку столика.
+func (s *Storage) CreateTableBlock(ctx context.Context, block *domain.TableBlock) error {
+	ctx, span := tracer.Start(ctx, "Storage.CreateTableBlock")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Create(models.ConvertTableBlockToModel(block)).Error
+}
+
+// DeleteTableBlock снимает блокировку столика и возвращает ее. Возвращает nil, если блокировка не найдена.
+func (s *Storage) DeleteTableBlock(ctx context.Context, restaurantID string, blockID string) (*domain.TableBlock, error) {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteTableBlock")
+	defer span.End()
+
+	var block models.TableBlock
+	result := s.Database.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND id = ?", restaurantID, blockID).
+		Delete(&block)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return models.ConvertTableBlockToDomain(&block), nil
+}
+
+// GetTableBlocks возвращает блокировки столиков ресторана, пересекающиеся с интервалом [from, to).
+func (s *Storage) GetTableBlocks(ctx context.Context, restaurantID string, from, to time.Time) ([]domain.TableBlock, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetTableBlocks")
+	defer span.End()
+
+	var dbBlocks []models.TableBlock
+	err := s.Database.WithContext(ctx).
+		Where("restaurant_id = ? AND start_time < ? AND end_time > ?", restaurantID, to, from).
+		Order("start_time").
+		Find(&dbBlocks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]domain.TableBlock, 0, len(dbBlocks))
+	for _, b := range dbBlocks {
+		blocks = append(blocks, *models.ConvertTableBlockToDomain(&b))
+	}
+	return blocks, nil
+}
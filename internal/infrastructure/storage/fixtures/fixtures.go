@@ -0,0 +1,251 @@
+package fixtures
+
+import (
+	"booking_system/internal/app/ports"
+	"booking_system/internal/domain"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// namespace используется для детерминированных идентификаторов: повторная загрузка
+// той же фикстуры обновляет записи, а не создает дубликаты.
+var namespace = uuid.MustParse("5b0d8f3e-8a4c-4c47-9a7e-3f1c2d6e9b10")
+
+// Fixture описывает набор демонстрационных данных. Формат — YAML или JSON.
+type Fixture struct {
+	Restaurants  []Restaurant  `yaml:"restaurants"`
+	Users        []User        `yaml:"users"`
+	Reservations []Reservation `yaml:"reservations"`
+}
+
+type Restaurant struct {
+	ID      string `yaml:"id"`
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	Phone   string `yaml:"phone"`
+	// Timezone — часовой пояс IANA, по умолчанию UTC.
+	Timezone string  `yaml:"timezone"`
+	Tables   []Table `yaml:"tables"`
+	// Managers — Telegram ID пользователей, управляющих рестораном.
+	Managers []int64 `yaml:"managers"`
+	// Hosts — Telegram ID хостов, рассаживающих гостей.
+	Hosts []int64 `yaml:"hosts"`
+}
+
+type Table struct {
+	ID       string   `yaml:"id"`
+	Number   int      `yaml:"number"`
+	Capacity int      `yaml:"capacity"`
+	Position Position `yaml:"position"`
+}
+
+type Position struct {
+	X float64 `yaml:"x"`
+	Y float64 `yaml:"y"`
+	Z float64 `yaml:"z"`
+}
+
+type User struct {
+	ID         string `yaml:"id"`
+	Name       string `yaml:"name"`
+	TelegramID int64  `yaml:"telegram_id"`
+	Phone      string `yaml:"phone"`
+}
+
+// Reservation ссылается на ресторан по имени, на пользователя по Telegram ID
+// и на столики по номерам внутри ресторана.
+type Reservation struct {
+	ID             string    `yaml:"id"`
+	Restaurant     string    `yaml:"restaurant"`
+	UserTelegramID int64     `yaml:"user_telegram_id"`
+	StartTime      time.Time `yaml:"start_time"`
+	EndTime        time.Time `yaml:"end_time"`
+	Status         string    `yaml:"status"`
+	Capacity       int       `yaml:"capacity"`
+	Contacts       Contacts  `yaml:"contacts"`
+	Tables         []int     `yaml:"tables"`
+}
+
+type Contacts struct {
+	Name  string `yaml:"name"`
+	Phone string `yaml:"phone"`
+}
+
+// Load читает фикстуру из файла. JSON является подмножеством YAML,
+// поэтому оба формата разбираются одним декодером.
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture Fixture
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// Loader загружает фикстуры в базу через слой хранилища.
+type Loader struct {
+	logger  *slog.Logger
+	storage ports.IStorage
+}
+
+func NewLoader(logger *slog.Logger, storage ports.IStorage) *Loader {
+	return &Loader{
+		logger:  logger,
+		storage: storage,
+	}
+}
+
+// Apply загружает фикстуру. Операция идемпотентна: записи без явного id
+// получают идентификатор, вычисленный из их естественного ключа.
+func (l *Loader) Apply(ctx context.Context, fixture *Fixture) error {
+	restaurantIDs := make(map[string]string, len(fixture.Restaurants))
+	tableIDs := make(map[string]map[int]string, len(fixture.Restaurants))
+	for _, r := range fixture.Restaurants {
+		restaurant := domain.Restaurant{
+			ID:       idOr(r.ID, "restaurant", r.Name),
+			Name:     r.Name,
+			Address:  r.Address,
+			Phone:    r.Phone,
+			Timezone: r.Timezone,
+		}
+		if restaurant.Timezone == "" {
+			restaurant.Timezone = "UTC"
+		}
+		if _, err := restaurant.Location(); err != nil {
+			return fmt.Errorf("restaurant %s: invalid timezone: %w", r.Name, err)
+		}
+		if err := l.storage.SaveRestaurant(ctx, &restaurant); err != nil {
+			return fmt.Errorf("restaurant %s: %w", r.Name, err)
+		}
+		restaurantIDs[r.Name] = restaurant.ID
+		tableIDs[r.Name] = make(map[int]string, len(r.Tables))
+
+		for _, t := range r.Tables {
+			table := domain.Table{
+				ID:           idOr(t.ID, "table", restaurant.ID, strconv.Itoa(t.Number)),
+				RestaurantID: restaurant.ID,
+				TableNumber:  t.Number,
+				Capacity:     t.Capacity,
+				PositionX:    t.Position.X,
+				PositionY:    t.Position.Y,
+				PositionZ:    t.Position.Z,
+			}
+			if err := l.storage.SaveTable(ctx, &table); err != nil {
+				return fmt.Errorf("restaurant %s table %d: %w", r.Name, t.Number, err)
+			}
+			tableIDs[r.Name][t.Number] = table.ID
+		}
+	}
+
+	userIDs := make(map[int64]string, len(fixture.Users))
+	for _, u := range fixture.Users {
+		user := domain.User{
+			ID:         idOr(u.ID, "user", strconv.FormatInt(u.TelegramID, 10)),
+			Name:       u.Name,
+			TelegramID: u.TelegramID,
+			Phone:      u.Phone,
+		}
+		// Пользователь мог уже авторизоваться через Telegram со случайным id.
+		exists, existing, err := l.storage.CheckUserForTelegram(ctx, u.TelegramID)
+		if err != nil {
+			return fmt.Errorf("user %d: %w", u.TelegramID, err)
+		}
+		if exists {
+			user.ID = existing.ID
+		}
+		if err := l.storage.SaveUser(ctx, &user); err != nil {
+			return fmt.Errorf("user %d: %w", u.TelegramID, err)
+		}
+		userIDs[u.TelegramID] = user.ID
+	}
+
+	for _, r := range fixture.Restaurants {
+		roles := map[string][]int64{
+			domain.StaffRoleManager: r.Managers,
+			domain.StaffRoleHost:    r.Hosts,
+		}
+		for role, telegramIDs := range roles {
+			for _, telegramID := range telegramIDs {
+				userID, ok := userIDs[telegramID]
+				if !ok {
+					return fmt.Errorf("restaurant %s: unknown %s %d", r.Name, role, telegramID)
+				}
+				staff := domain.RestaurantStaff{
+					RestaurantID: restaurantIDs[r.Name],
+					UserID:       userID,
+					Role:         role,
+				}
+				if err := l.storage.SaveStaff(ctx, &staff); err != nil {
+					return fmt.Errorf("restaurant %s %s %d: %w", r.Name, role, telegramID, err)
+				}
+			}
+		}
+	}
+
+	for i, r := range fixture.Reservations {
+		restaurantID, ok := restaurantIDs[r.Restaurant]
+		if !ok {
+			return fmt.Errorf("reservation #%d: unknown restaurant %q", i, r.Restaurant)
+		}
+		userID, ok := userIDs[r.UserTelegramID]
+		if !ok {
+			return fmt.Errorf("reservation #%d: unknown user %d", i, r.UserTelegramID)
+		}
+		status := r.Status
+		if status == "" {
+			status = "wait"
+		}
+		reservation := domain.Reservation{
+			ID:           idOr(r.ID, "reservation", restaurantID, userID, r.StartTime.UTC().Format(time.RFC3339)),
+			UserID:       userID,
+			RestaurantID: restaurantID,
+			StartTime:    r.StartTime,
+			EndTime:      r.EndTime,
+			Status:       status,
+			Capacity:     r.Capacity,
+			Contacts: domain.Contacts{
+				Name:  r.Contacts.Name,
+				Phone: r.Contacts.Phone,
+			},
+		}
+		links := make(map[string]string, len(r.Tables))
+		for _, number := range r.Tables {
+			tableID, ok := tableIDs[r.Restaurant][number]
+			if !ok {
+				return fmt.Errorf("reservation #%d: unknown table %d in restaurant %q", i, number, r.Restaurant)
+			}
+			links[idOr("", "reservation_table", reservation.ID, tableID)] = tableID
+		}
+		if err := l.storage.SaveReservation(ctx, &reservation, links); err != nil {
+			return fmt.Errorf("reservation #%d: %w", i, err)
+		}
+	}
+
+	l.logger.Info("Fixture applied",
+		"restaurants", len(fixture.Restaurants),
+		"users", len(fixture.Users),
+		"reservations", len(fixture.Reservations),
+	)
+	return nil
+}
+
+// idOr возвращает id, если он задан, иначе UUIDv5 от естественного ключа записи.
+func idOr(id string, kind string, key ...string) string {
+	if id != "" {
+		return id
+	}
+	name := kind
+	for _, k := range key {
+		name += ":" + k
+	}
+	return uuid.NewSHA1(namespace, []byte(name)).String()
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// AcquireIdempotencyKey занимает ключ идемпотентности под новый запрос. Если ключ уже занят
+// действующей записью, возвращает ее; истекший или брошенный ключ занимается заново.
+func (s *Storage) AcquireIdempotencyKey(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	ctx, span := tracer.Start(ctx, "Storage.AcquireIdempotencyKey")
+	defer span.End()
+
+	now := record.CreatedAt
+	result := s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "response", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{gorm.Expr(
+			"idempotency_keys.expires_at <= ? OR (idempotency_keys.response IS NULL AND idempotency_keys.created_at <= ?)",
+			now, now.Add(-domain.IdempotencyLockTimeout),
+		)}},
+	}).Create(models.ConvertIdempotencyKeyToModel(record))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing models.IdempotencyKey
+	err := s.Database.WithContext(ctx).First(&existing, "user_id = ? AND key = ?", record.UserID, record.Key).Error
+	if err != nil {
+		return nil, err
+	}
+	return models.ConvertIdempotencyKeyToDomain(&existing), nil
+}
+
+// CompleteIdempotencyKey сохраняет результат запроса, занявшего ключ.
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, userID string, key string, response []byte) error {
+	ctx, span := tracer.Start(ctx, "Storage.CompleteIdempotencyKey")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Model(&models.IdempotencyKey{}).
+		Where("user_id = ? AND key = ?", userID, key).
+		Update("response", response).Error
+}
+
+// ReleaseIdempotencyKey освобождает ключ незавершенного запроса, чтобы его можно было повторить.
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, userID string, key string) error {
+	ctx, span := tracer.Start(ctx, "Storage.ReleaseIdempotencyKey")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).
+		Delete(&models.IdempotencyKey{}, "user_id = ? AND key = ? AND response IS NULL", userID, key).Error
+}
+
+// DeleteExpiredIdempotencyKeys удаляет ключи, срок хранения которых истек к now.
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteExpiredIdempotencyKeys")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).Delete(&models.IdempotencyKey{}, "expires_at <= ?", now)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,165 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"io/fs"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+const migrationsTable = "schema_migrations"
+
+// Migrator применяет версионные миграции схемы, встроенные в бинарник.
+type Migrator struct {
+	logger *slog.Logger
+	db     *sql.DB
+}
+
+func New(logger *slog.Logger, db *sql.DB) *Migrator {
+	return &Migrator{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Up применяет все непримененные миграции.
+func (m *Migrator) Up() error {
+	mg, err := m.migrate()
+	if err != nil {
+		return err
+	}
+	if err := mg.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down откатывает steps последних миграций.
+func (m *Migrator) Down(steps int) error {
+	mg, err := m.migrate()
+	if err != nil {
+		return err
+	}
+	if err := mg.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force выставляет версию схемы без выполнения миграций и снимает флаг dirty.
+// Используется для ручного восстановления после упавшей миграции.
+func (m *Migrator) Force(version int) error {
+	mg, err := m.migrate()
+	if err != nil {
+		return err
+	}
+	return mg.Force(version)
+}
+
+// Version возвращает текущую версию схемы в базе. Для пустой базы — 0.
+func (m *Migrator) Version(ctx context.Context) (uint, bool, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", migrationsTable).Scan(&exists)
+	if err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		return 0, false, nil
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err = m.db.QueryRowContext(ctx, "SELECT version, dirty FROM "+migrationsTable+" LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+// LatestVersion возвращает версию последней миграции, встроенной в бинарник.
+func LatestVersion() (uint, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %s: %w", entry.Name(), err)
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	return latest, nil
+}
+
+// CheckVersion проверяет, что схема базы совпадает с версией, ожидаемой бинарником.
+func (m *Migrator) CheckVersion(ctx context.Context) error {
+	latest, err := LatestVersion()
+	if err != nil {
+		return err
+	}
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty", version)
+	}
+	if version != latest {
+		return fmt.Errorf("schema version %d, expected %d", version, latest)
+	}
+	return nil
+}
+
+func (m *Migrator) migrate() (*migrate.Migrate, error) {
+	source, err := iofs.New(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	driver, err := pgx.WithInstance(m.db, &pgx.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		return nil, err
+	}
+	mg, err := migrate.NewWithInstance("iofs", source, "pgx5", driver)
+	if err != nil {
+		return nil, err
+	}
+	mg.Log = migrateLogger{logger: m.logger}
+	return mg, nil
+}
+
+// migrateLogger перенаправляет вывод golang-migrate в slog.
+type migrateLogger struct {
+	logger *slog.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...interface{}) {
+	l.logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
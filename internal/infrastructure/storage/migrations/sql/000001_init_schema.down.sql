@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS reservation_tables;
+DROP TABLE IF EXISTS reservations;
+DROP TABLE IF EXISTS tables;
+DROP TABLE IF EXISTS restaurants;
+DROP TABLE IF EXISTS users;
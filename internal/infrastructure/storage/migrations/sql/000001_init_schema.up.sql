@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS users
+(
+    id          TEXT PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    telegram_id BIGINT       NOT NULL UNIQUE,
+    phone       VARCHAR(15),
+    created_at  TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS restaurants
+(
+    id         TEXT PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    address    VARCHAR(255) NOT NULL,
+    phone      VARCHAR(15),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tables
+(
+    id            TEXT PRIMARY KEY,
+    restaurant_id TEXT             NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    table_number  BIGINT           NOT NULL,
+    capacity      BIGINT           NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    position_x    DOUBLE PRECISION NOT NULL,
+    position_y    DOUBLE PRECISION NOT NULL,
+    position_z    DOUBLE PRECISION NOT NULL,
+    CONSTRAINT chk_tables_capacity CHECK (capacity > 0),
+    CONSTRAINT uq_tables_restaurant_number UNIQUE (restaurant_id, table_number)
+);
+
+CREATE TABLE IF NOT EXISTS reservations
+(
+    id            TEXT PRIMARY KEY,
+    user_id       TEXT        NOT NULL REFERENCES users (id),
+    restaurant_id TEXT        NOT NULL REFERENCES restaurants (id),
+    start_time    TIMESTAMPTZ NOT NULL,
+    end_time      TIMESTAMPTZ NOT NULL,
+    status        VARCHAR(50) NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    capacity      BIGINT      NOT NULL,
+    contacts      JSONB,
+    CONSTRAINT chk_reservations_status CHECK (status IN ('wait', 'sucess', 'canceled')),
+    CONSTRAINT chk_reservations_time CHECK (end_time > start_time),
+    CONSTRAINT chk_reservations_capacity CHECK (capacity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_restaurant_start ON reservations (restaurant_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_reservations_user_start ON reservations (user_id, start_time);
+
+CREATE TABLE IF NOT EXISTS reservation_tables
+(
+    id             TEXT PRIMARY KEY,
+    reservation_id TEXT NOT NULL REFERENCES reservations (id) ON DELETE CASCADE,
+    table_id       TEXT NOT NULL REFERENCES tables (id),
+    created_at     TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_reservation_tables UNIQUE (reservation_id, table_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_tables_table ON reservation_tables (table_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS restaurant_staff;
+DROP TABLE IF EXISTS booking_policies;
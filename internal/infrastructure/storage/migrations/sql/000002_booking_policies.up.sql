@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS booking_policies
+(
+    restaurant_id            TEXT PRIMARY KEY REFERENCES restaurants (id) ON DELETE CASCADE,
+    min_duration_minutes     BIGINT NOT NULL DEFAULT 0,
+    max_duration_minutes     BIGINT NOT NULL DEFAULT 0,
+    slot_granularity_minutes BIGINT NOT NULL DEFAULT 0,
+    lead_time_minutes        BIGINT NOT NULL DEFAULT 0,
+    booking_horizon_minutes  BIGINT NOT NULL DEFAULT 0,
+    max_tables_per_booking   BIGINT NOT NULL DEFAULT 0,
+    max_party_size           BIGINT NOT NULL DEFAULT 0,
+    turnover_buffer_minutes  BIGINT NOT NULL DEFAULT 0,
+    updated_at               TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_booking_policies_non_negative CHECK (
+        min_duration_minutes >= 0 AND max_duration_minutes >= 0 AND slot_granularity_minutes >= 0 AND
+        lead_time_minutes >= 0 AND booking_horizon_minutes >= 0 AND max_tables_per_booking >= 0 AND
+        max_party_size >= 0 AND turnover_buffer_minutes >= 0
+        ),
+    CONSTRAINT chk_booking_policies_duration CHECK (
+        max_duration_minutes = 0 OR max_duration_minutes >= min_duration_minutes
+        )
+);
+
+CREATE TABLE IF NOT EXISTS restaurant_staff
+(
+    restaurant_id TEXT        NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    user_id       TEXT        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role          VARCHAR(50) NOT NULL,
+    created_at    TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (restaurant_id, user_id),
+    CONSTRAINT chk_restaurant_staff_role CHECK (role IN ('manager'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_restaurant_staff_user ON restaurant_staff (user_id);
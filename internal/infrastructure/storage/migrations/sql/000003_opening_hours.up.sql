@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS opening_hours
+(
+    restaurant_id TEXT   NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    weekday       BIGINT NOT NULL,
+    open_minute   BIGINT NOT NULL,
+    close_minute  BIGINT NOT NULL,
+    PRIMARY KEY (restaurant_id, weekday, open_minute),
+    CONSTRAINT chk_opening_hours_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_opening_hours_minutes CHECK (open_minute BETWEEN 0 AND 1439 AND close_minute BETWEEN 0 AND 1439)
+);
+
+CREATE TABLE IF NOT EXISTS special_days
+(
+    restaurant_id TEXT    NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    date          DATE    NOT NULL,
+    closed        BOOLEAN NOT NULL DEFAULT FALSE,
+    open_minute   BIGINT  NOT NULL DEFAULT 0,
+    close_minute  BIGINT  NOT NULL DEFAULT 0,
+    note          VARCHAR(255),
+    PRIMARY KEY (restaurant_id, date),
+    CONSTRAINT chk_special_days_minutes CHECK (open_minute BETWEEN 0 AND 1439 AND close_minute BETWEEN 0 AND 1439)
+);
+
+CREATE TABLE IF NOT EXISTS closures
+(
+    id            TEXT PRIMARY KEY,
+    restaurant_id TEXT        NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    start_time    TIMESTAMPTZ NOT NULL,
+    end_time      TIMESTAMPTZ NOT NULL,
+    reason        VARCHAR(255),
+    created_at    TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_closures_time CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_closures_restaurant_time ON closures (restaurant_id, start_time, end_time);
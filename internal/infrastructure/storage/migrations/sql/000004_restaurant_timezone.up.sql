@@ -0,0 +1,2 @@
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
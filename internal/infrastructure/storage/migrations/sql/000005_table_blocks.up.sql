@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS table_blocks
+(
+    id            TEXT PRIMARY KEY,
+    table_id      TEXT         NOT NULL REFERENCES tables (id) ON DELETE CASCADE,
+    restaurant_id TEXT         NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    start_time    TIMESTAMPTZ  NOT NULL,
+    end_time      TIMESTAMPTZ  NOT NULL,
+    reason        VARCHAR(255) NOT NULL,
+    created_by    TEXT         NOT NULL REFERENCES users (id),
+    created_at    TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_table_blocks_time CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_table_blocks_table_time ON table_blocks (table_id, start_time, end_time);
+CREATE INDEX IF NOT EXISTS idx_table_blocks_restaurant_time ON table_blocks (restaurant_id, start_time);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS waitlist_entries
+(
+    id               TEXT PRIMARY KEY,
+    user_id          TEXT        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    restaurant_id    TEXT        NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    start_time       TIMESTAMPTZ NOT NULL,
+    end_time         TIMESTAMPTZ NOT NULL,
+    capacity         BIGINT      NOT NULL,
+    contacts         JSONB,
+    status           VARCHAR(50) NOT NULL,
+    offered_tables   JSONB       NOT NULL DEFAULT '[]',
+    offer_expires_at TIMESTAMPTZ,
+    reservation_id   TEXT,
+    created_at       TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_waitlist_status CHECK (status IN ('waiting', 'offered', 'claimed', 'expired', 'canceled')),
+    CONSTRAINT chk_waitlist_time CHECK (end_time > start_time),
+    CONSTRAINT chk_waitlist_capacity CHECK (capacity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_restaurant_status_time ON waitlist_entries (restaurant_id, status, start_time);
+CREATE INDEX IF NOT EXISTS idx_waitlist_user ON waitlist_entries (user_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_offer_expires ON waitlist_entries (offer_expires_at) WHERE status = 'offered';
@@ -0,0 +1,24 @@
+DELETE FROM restaurant_staff WHERE role = 'host';
+
+ALTER TABLE restaurant_staff
+    DROP CONSTRAINT IF EXISTS chk_restaurant_staff_role;
+
+ALTER TABLE restaurant_staff
+    ADD CONSTRAINT chk_restaurant_staff_role CHECK (role IN ('manager'));
+
+DROP INDEX IF EXISTS idx_reservations_seated;
+
+DELETE FROM reservations WHERE user_id IS NULL;
+
+ALTER TABLE reservations
+    DROP CONSTRAINT IF EXISTS chk_reservations_left,
+    DROP CONSTRAINT IF EXISTS chk_reservations_user,
+    DROP CONSTRAINT IF EXISTS chk_reservations_source;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS left_at,
+    DROP COLUMN IF EXISTS seated_at,
+    DROP COLUMN IF EXISTS source;
+
+ALTER TABLE reservations
+    ALTER COLUMN user_id SET NOT NULL;
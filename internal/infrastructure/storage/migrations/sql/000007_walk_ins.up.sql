@@ -0,0 +1,22 @@
+ALTER TABLE reservations
+    ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS source    VARCHAR(20) NOT NULL DEFAULT 'online',
+    ADD COLUMN IF NOT EXISTS seated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS left_at   TIMESTAMPTZ;
+
+ALTER TABLE reservations
+    ADD CONSTRAINT chk_reservations_source CHECK (source IN ('online', 'walk_in')),
+    ADD CONSTRAINT chk_reservations_user CHECK (user_id IS NOT NULL OR source = 'walk_in'),
+    ADD CONSTRAINT chk_reservations_left CHECK (left_at IS NULL OR seated_at IS NOT NULL);
+
+-- Рассаженные гости, которые еще не ушли: по ним строится текущая занятость зала.
+CREATE INDEX IF NOT EXISTS idx_reservations_seated ON reservations (restaurant_id)
+    WHERE seated_at IS NOT NULL AND left_at IS NULL;
+
+ALTER TABLE restaurant_staff
+    DROP CONSTRAINT IF EXISTS chk_restaurant_staff_role;
+
+ALTER TABLE restaurant_staff
+    ADD CONSTRAINT chk_restaurant_staff_role CHECK (role IN ('manager', 'host'));
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS reservation_series
+(
+    id            TEXT PRIMARY KEY,
+    user_id       TEXT        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    restaurant_id TEXT        NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    frequency     VARCHAR(20) NOT NULL,
+    until         TIMESTAMPTZ,
+    count         BIGINT      NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_reservation_series_frequency CHECK (frequency IN ('weekly', 'biweekly', 'monthly')),
+    -- Серия ограничена либо датой, либо числом броней
+    CONSTRAINT chk_reservation_series_bound CHECK ((until IS NULL) <> (count = 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_series_user ON reservation_series (user_id);
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS series_id TEXT REFERENCES reservation_series (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reservations_series ON reservations (series_id) WHERE series_id IS NOT NULL;
@@ -0,0 +1,18 @@
+ALTER TABLE booking_policies
+    DROP CONSTRAINT IF EXISTS chk_booking_policies_event_duration;
+
+ALTER TABLE booking_policies
+    DROP COLUMN IF EXISTS event_max_duration_minutes,
+    DROP COLUMN IF EXISTS event_min_duration_minutes;
+
+DROP INDEX IF EXISTS idx_reservations_pending_events;
+
+ALTER TABLE reservations
+    DROP CONSTRAINT IF EXISTS chk_reservations_scope;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS zone_id,
+    DROP COLUMN IF EXISTS scope;
+
+DROP TABLE IF EXISTS zone_tables;
+DROP TABLE IF EXISTS zones;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS zones
+(
+    id                   TEXT PRIMARY KEY,
+    restaurant_id        TEXT         NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    name                 VARCHAR(255) NOT NULL,
+    min_duration_minutes BIGINT       NOT NULL DEFAULT 0,
+    max_duration_minutes BIGINT       NOT NULL DEFAULT 0,
+    requires_approval    BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at           TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_zones_restaurant_name UNIQUE (restaurant_id, name),
+    CONSTRAINT chk_zones_duration CHECK (
+        min_duration_minutes >= 0 AND max_duration_minutes >= 0 AND
+        (max_duration_minutes = 0 OR max_duration_minutes >= min_duration_minutes)
+        )
+);
+
+CREATE TABLE IF NOT EXISTS zone_tables
+(
+    zone_id  TEXT NOT NULL REFERENCES zones (id) ON DELETE CASCADE,
+    table_id TEXT NOT NULL REFERENCES tables (id) ON DELETE CASCADE,
+    PRIMARY KEY (zone_id, table_id)
+);
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS scope   VARCHAR(20) NOT NULL DEFAULT 'tables',
+    ADD COLUMN IF NOT EXISTS zone_id TEXT REFERENCES zones (id) ON DELETE SET NULL;
+
+ALTER TABLE reservations
+    ADD CONSTRAINT chk_reservations_scope CHECK (scope IN ('tables', 'zone', 'venue'));
+
+-- Брони зон и выкупы, ожидающие решения менеджера
+CREATE INDEX IF NOT EXISTS idx_reservations_pending_events ON reservations (restaurant_id, start_time)
+    WHERE scope <> 'tables' AND status = 'wait';
+
+ALTER TABLE booking_policies
+    ADD COLUMN IF NOT EXISTS event_min_duration_minutes BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS event_max_duration_minutes BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE booking_policies
+    ADD CONSTRAINT chk_booking_policies_event_duration CHECK (
+        event_min_duration_minutes >= 0 AND event_max_duration_minutes >= 0 AND
+        (event_max_duration_minutes = 0 OR event_max_duration_minutes >= event_min_duration_minutes)
+        );
@@ -0,0 +1,20 @@
+ALTER TABLE booking_policies
+    DROP CONSTRAINT IF EXISTS chk_booking_policies_deposit;
+
+ALTER TABLE booking_policies
+    DROP COLUMN IF EXISTS refund_window_minutes,
+    DROP COLUMN IF EXISTS payment_hold_minutes,
+    DROP COLUMN IF EXISTS peak_end_minute,
+    DROP COLUMN IF EXISTS peak_start_minute,
+    DROP COLUMN IF EXISTS deposit_per_guest,
+    DROP COLUMN IF EXISTS deposit_party_size;
+
+DROP TABLE IF EXISTS payments;
+
+UPDATE reservations SET status = 'canceled' WHERE status = 'pending_payment';
+
+ALTER TABLE reservations
+    DROP CONSTRAINT IF EXISTS chk_reservations_status;
+
+ALTER TABLE reservations
+    ADD CONSTRAINT chk_reservations_status CHECK (status IN ('wait', 'sucess', 'canceled'));
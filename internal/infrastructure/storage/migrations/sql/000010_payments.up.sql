@@ -0,0 +1,40 @@
+ALTER TABLE reservations
+    DROP CONSTRAINT IF EXISTS chk_reservations_status;
+
+ALTER TABLE reservations
+    ADD CONSTRAINT chk_reservations_status CHECK (status IN ('wait', 'sucess', 'canceled', 'pending_payment'));
+
+CREATE TABLE IF NOT EXISTS payments
+(
+    id               TEXT PRIMARY KEY,
+    reservation_id   TEXT          NOT NULL REFERENCES reservations (id) ON DELETE CASCADE,
+    provider         VARCHAR(50)   NOT NULL,
+    external_id      VARCHAR(255)  NOT NULL,
+    amount           BIGINT        NOT NULL,
+    currency         VARCHAR(3)    NOT NULL,
+    status           VARCHAR(20)   NOT NULL,
+    confirmation_url VARCHAR(1024),
+    expires_at       TIMESTAMPTZ   NOT NULL,
+    created_at       TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_payments_status CHECK (status IN ('pending', 'paid', 'failed', 'expired', 'canceled', 'refunded')),
+    CONSTRAINT chk_payments_amount CHECK (amount > 0),
+    CONSTRAINT uq_payments_provider_external UNIQUE (provider, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_reservation ON payments (reservation_id);
+CREATE INDEX IF NOT EXISTS idx_payments_pending_expires ON payments (expires_at) WHERE status = 'pending';
+
+ALTER TABLE booking_policies
+    ADD COLUMN IF NOT EXISTS deposit_party_size    BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS deposit_per_guest     BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS peak_start_minute     BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS peak_end_minute       BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS payment_hold_minutes  BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS refund_window_minutes BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE booking_policies
+    ADD CONSTRAINT chk_booking_policies_deposit CHECK (
+        deposit_party_size >= 0 AND deposit_per_guest >= 0 AND payment_hold_minutes >= 0 AND
+        refund_window_minutes >= 0 AND peak_start_minute BETWEEN 0 AND 1439 AND peak_end_minute BETWEEN 0 AND 1439
+        );
@@ -0,0 +1,10 @@
+ALTER TABLE booking_policies
+    DROP CONSTRAINT IF EXISTS chk_booking_policies_cancellation;
+
+ALTER TABLE booking_policies
+    DROP COLUMN IF EXISTS cancellation_cutoff_minutes;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS no_show,
+    DROP COLUMN IF EXISTS late_cancel,
+    DROP COLUMN IF EXISTS canceled_at;
@@ -0,0 +1,10 @@
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS canceled_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS late_cancel BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS no_show     BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE booking_policies
+    ADD COLUMN IF NOT EXISTS cancellation_cutoff_minutes BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE booking_policies
+    ADD CONSTRAINT chk_booking_policies_cancellation CHECK (cancellation_cutoff_minutes >= 0);
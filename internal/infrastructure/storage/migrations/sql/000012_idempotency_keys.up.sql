@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    user_id     TEXT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    key         VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64)  NOT NULL,
+    response    BYTEA,
+    created_at  TIMESTAMPTZ  NOT NULL,
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys (expires_at);
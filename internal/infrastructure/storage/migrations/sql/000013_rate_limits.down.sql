@@ -0,0 +1,7 @@
+ALTER TABLE booking_policies
+    DROP CONSTRAINT IF EXISTS chk_booking_policies_max_active_bookings;
+
+ALTER TABLE booking_policies
+    DROP COLUMN IF EXISTS max_active_bookings;
+
+DROP TABLE IF EXISTS rate_limit_buckets;
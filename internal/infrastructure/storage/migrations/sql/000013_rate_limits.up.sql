@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS rate_limit_buckets
+(
+    key        VARCHAR(255) PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ      NOT NULL,
+    full_at    TIMESTAMPTZ      NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_full_at ON rate_limit_buckets (full_at);
+
+ALTER TABLE booking_policies
+    ADD COLUMN IF NOT EXISTS max_active_bookings BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE booking_policies
+    ADD CONSTRAINT chk_booking_policies_max_active_bookings CHECK (max_active_bookings >= 0);
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id            TEXT PRIMARY KEY,
+    restaurant_id TEXT         NOT NULL,
+    entity_type   VARCHAR(20)  NOT NULL,
+    entity_id     TEXT         NOT NULL,
+    action        VARCHAR(50)  NOT NULL,
+    actor_id      TEXT         NOT NULL,
+    request_id    VARCHAR(128),
+    changes       JSONB        NOT NULL DEFAULT '{}',
+    created_at    TIMESTAMPTZ  NOT NULL,
+    CONSTRAINT chk_audit_log_entity_type CHECK (entity_type IN ('reservation', 'table', 'restaurant'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_restaurant_created ON audit_log (restaurant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, created_at DESC);
+
+-- Журнал только дополняется: изменить или удалить запись нельзя даже напрямую в базе.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_append_only();
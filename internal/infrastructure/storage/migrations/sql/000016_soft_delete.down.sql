@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS uq_tables_restaurant_number;
+ALTER TABLE tables
+    ADD CONSTRAINT uq_tables_restaurant_number UNIQUE (restaurant_id, table_number);
+
+DROP INDEX IF EXISTS idx_reservations_deleted_at;
+DROP INDEX IF EXISTS idx_tables_deleted_at;
+DROP INDEX IF EXISTS idx_restaurants_deleted_at;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tables
+    DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE restaurants
+    DROP COLUMN IF EXISTS deleted_at;
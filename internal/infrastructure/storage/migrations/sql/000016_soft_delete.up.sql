@@ -0,0 +1,15 @@
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE tables
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_deleted_at ON restaurants (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tables_deleted_at ON tables (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_deleted_at ON reservations (deleted_at);
+
+-- Номер выведенного из зала столика можно снова выдать новому столику.
+ALTER TABLE tables
+    DROP CONSTRAINT IF EXISTS uq_tables_restaurant_number;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tables_restaurant_number ON tables (restaurant_id, table_number) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+-- Стертые контакты не восстанавливаются, откатывается только исключение в триггере.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS audit_log_redact_contacts(JSONB);
+DROP FUNCTION IF EXISTS audit_log_redact_value(JSONB);
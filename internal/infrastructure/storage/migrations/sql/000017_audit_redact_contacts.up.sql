@@ -0,0 +1,38 @@
+-- Контакты гостя в журнале заменяются на "redacted"; null остается, чтобы было видно,
+-- что поле появилось или исчезло.
+CREATE OR REPLACE FUNCTION audit_log_redact_value(value JSONB) RETURNS JSONB AS
+$$
+SELECT CASE WHEN value IS NULL OR value = 'null'::JSONB THEN value ELSE '"redacted"'::JSONB END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION audit_log_redact_contacts(changes JSONB) RETURNS JSONB AS
+$$
+SELECT CASE
+           WHEN jsonb_exists(changes, 'contacts') THEN jsonb_set(changes, '{contacts}', jsonb_build_object(
+                   'from', audit_log_redact_value(changes -> 'contacts' -> 'from'),
+                   'to', audit_log_redact_value(changes -> 'contacts' -> 'to')))
+           ELSE changes
+           END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Журнал по-прежнему только дополняется. Единственное разрешенное изменение записи —
+-- обезличивание контактов гостя через audit_log_redact_contacts, остальные поля меняться не могут.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND ROW (NEW.id, NEW.restaurant_id, NEW.entity_type, NEW.entity_id, NEW.action, NEW.actor_id, NEW.request_id, NEW.created_at)
+            IS NOT DISTINCT FROM
+            ROW (OLD.id, OLD.restaurant_id, OLD.entity_type, OLD.entity_id, OLD.action, OLD.actor_id, OLD.request_id, OLD.created_at)
+        AND NEW.changes = audit_log_redact_contacts(OLD.changes) THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Записи, сделанные до обезличивания, чистятся сразу.
+UPDATE audit_log
+SET changes = audit_log_redact_contacts(changes)
+WHERE jsonb_exists(changes, 'contacts')
+  AND changes <> audit_log_redact_contacts(changes);
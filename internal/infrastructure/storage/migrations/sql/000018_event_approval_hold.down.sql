@@ -0,0 +1,10 @@
+ALTER TABLE booking_policies
+    DROP CONSTRAINT IF EXISTS chk_booking_policies_approval_hold;
+
+ALTER TABLE booking_policies
+    DROP COLUMN IF EXISTS approval_hold_minutes;
+
+DROP INDEX IF EXISTS idx_reservations_approval_expires;
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS approval_expires_at;
@@ -0,0 +1,17 @@
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS approval_expires_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_reservations_approval_expires ON reservations (approval_expires_at) WHERE status = 'wait';
+
+-- Брони зон и выкупы, которые уже ждут решения менеджера, получают срок по умолчанию.
+UPDATE reservations
+SET approval_expires_at = LEAST(start_time, CURRENT_TIMESTAMP + INTERVAL '24 hours')
+WHERE status = 'wait'
+  AND scope IN ('zone', 'venue')
+  AND approval_expires_at IS NULL;
+
+ALTER TABLE booking_policies
+    ADD COLUMN IF NOT EXISTS approval_hold_minutes BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE booking_policies
+    ADD CONSTRAINT chk_booking_policies_approval_hold CHECK (approval_hold_minutes >= 0);
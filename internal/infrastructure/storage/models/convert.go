@@ -18,10 +18,11 @@ func ConvertUserToDomain(u *User) *domain.User {
 // ConvertRestaurantToDomain конвертирует модель Restaurant в доменный объект Restaurant.
 func ConvertRestaurantToDomain(r *Restaurant) *domain.Restaurant {
 	return &domain.Restaurant{
-		ID:      r.ID,
-		Name:    r.Name,
-		Address: r.Address,
-		Phone:   r.Phone,
+		ID:       r.ID,
+		Name:     r.Name,
+		Address:  r.Address,
+		Phone:    r.Phone,
+		Timezone: r.Timezone,
 	}
 }
 
@@ -40,9 +41,8 @@ func ConvertTableToDomain(t *Table) *domain.Table {
 
 // ConvertReservationToDomain конвертирует модель Reservation в доменный объект Reservation.
 func ConvertReservationToDomain(r *Reservation) *domain.Reservation {
-	return &domain.Reservation{
+	reservation := &domain.Reservation{
 		ID:           r.ID,
-		UserID:       r.UserID,
 		RestaurantID: r.RestaurantID,
 		StartTime:    r.StartTime,
 		EndTime:      r.EndTime,
@@ -51,8 +51,35 @@ func ConvertReservationToDomain(r *Reservation) *domain.Reservation {
 			Name:  r.Contacts.Name,
 			Phone: r.Contacts.Phone,
 		},
-		Capacity: r.Capacity,
+		Capacity:   r.Capacity,
+		Source:     r.Source,
+		Scope:      r.Scope,
+		LateCancel: r.LateCancel,
+		NoShow:     r.NoShow,
+		Version:    r.Version,
 	}
+	if r.UserID != nil {
+		reservation.UserID = *r.UserID
+	}
+	if r.SeatedAt != nil {
+		reservation.SeatedAt = *r.SeatedAt
+	}
+	if r.LeftAt != nil {
+		reservation.LeftAt = *r.LeftAt
+	}
+	if r.SeriesID != nil {
+		reservation.SeriesID = *r.SeriesID
+	}
+	if r.ZoneID != nil {
+		reservation.ZoneID = *r.ZoneID
+	}
+	if r.CanceledAt != nil {
+		reservation.CanceledAt = *r.CanceledAt
+	}
+	if r.ApprovalExpiresAt != nil {
+		reservation.ApprovalExpiresAt = *r.ApprovalExpiresAt
+	}
+	return reservation
 }
 
 // ConvertReservationTableToDomain конвертирует модель ReservationTable в доменный объект ReservationTable.
@@ -82,6 +109,7 @@ func ConvertRestaurantToModel(r *domain.Restaurant) *Restaurant {
 		Name:      r.Name,
 		Address:   r.Address,
 		Phone:     r.Phone,
+		Timezone:  r.Timezone,
 		CreatedAt: time.Now(),
 	}
 }
@@ -102,13 +130,17 @@ func ConvertTableToModel(t *domain.Table) *Table {
 
 // ConvertReservationToModel конвертирует доменный объект Reservation в модель Reservation.
 func ConvertReservationToModel(r *domain.Reservation) *Reservation {
-	return &Reservation{
+	reservation := &Reservation{
 		ID:           r.ID,
-		UserID:       r.UserID,
 		RestaurantID: r.RestaurantID,
 		StartTime:    r.StartTime,
 		EndTime:      r.EndTime,
 		Status:       r.Status,
+		Source:       r.Source,
+		Scope:        r.Scope,
+		LateCancel:   r.LateCancel,
+		NoShow:       r.NoShow,
+		Version:      r.Version,
 		CreatedAt:    time.Now(),
 		Capacity:     r.Capacity,
 		Contacts: Contact{
@@ -116,6 +148,41 @@ func ConvertReservationToModel(r *domain.Reservation) *Reservation {
 			Phone: r.Contacts.Phone,
 		},
 	}
+	if reservation.Source == "" {
+		reservation.Source = domain.ReservationSourceOnline
+	}
+	if reservation.Scope == "" {
+		reservation.Scope = domain.ReservationScopeTables
+	}
+	if r.UserID != "" {
+		userID := r.UserID
+		reservation.UserID = &userID
+	}
+	if !r.SeatedAt.IsZero() {
+		seatedAt := r.SeatedAt
+		reservation.SeatedAt = &seatedAt
+	}
+	if !r.LeftAt.IsZero() {
+		leftAt := r.LeftAt
+		reservation.LeftAt = &leftAt
+	}
+	if r.SeriesID != "" {
+		seriesID := r.SeriesID
+		reservation.SeriesID = &seriesID
+	}
+	if r.ZoneID != "" {
+		zoneID := r.ZoneID
+		reservation.ZoneID = &zoneID
+	}
+	if !r.CanceledAt.IsZero() {
+		canceledAt := r.CanceledAt
+		reservation.CanceledAt = &canceledAt
+	}
+	if !r.ApprovalExpiresAt.IsZero() {
+		approvalExpiresAt := r.ApprovalExpiresAt
+		reservation.ApprovalExpiresAt = &approvalExpiresAt
+	}
+	return reservation
 }
 
 // ConvertReservationTableToModel конвертирует доменный объект ReservationTable в модель ReservationTable.
@@ -127,3 +194,379 @@ func ConvertReservationTableToModel(rt *domain.ReservationTable) *ReservationTab
 		CreatedAt:     time.Now(),
 	}
 }
+
+// ConvertBookingPolicyToDomain конвертирует модель BookingPolicy в доменный объект BookingPolicy.
+func ConvertBookingPolicyToDomain(p *BookingPolicy) *domain.BookingPolicy {
+	return &domain.BookingPolicy{
+		RestaurantID:        p.RestaurantID,
+		MinDuration:         minutes(p.MinDurationMinutes),
+		MaxDuration:         minutes(p.MaxDurationMinutes),
+		SlotGranularity:     minutes(p.SlotGranularityMinutes),
+		LeadTime:            minutes(p.LeadTimeMinutes),
+		BookingHorizon:      minutes(p.BookingHorizonMinutes),
+		MaxTablesPerBooking: p.MaxTablesPerBooking,
+		MaxPartySize:        p.MaxPartySize,
+		TurnoverBuffer:      minutes(p.TurnoverBufferMinutes),
+		EventMinDuration:    minutes(p.EventMinDurationMinutes),
+		EventMaxDuration:    minutes(p.EventMaxDurationMinutes),
+		DepositPartySize:    p.DepositPartySize,
+		DepositPerGuest:     p.DepositPerGuest,
+		PeakStart:           minutes(p.PeakStartMinute),
+		PeakEnd:             minutes(p.PeakEndMinute),
+		PaymentHold:         minutes(p.PaymentHoldMinutes),
+		RefundWindow:        minutes(p.RefundWindowMinutes),
+		CancellationCutoff:  minutes(p.CancellationCutoffMinutes),
+		MaxActiveBookings:   p.MaxActiveBookings,
+		ApprovalHold:        minutes(p.ApprovalHoldMinutes),
+	}
+}
+
+// ConvertBookingPolicyToModel конвертирует доменный объект BookingPolicy в модель BookingPolicy.
+func ConvertBookingPolicyToModel(p *domain.BookingPolicy) *BookingPolicy {
+	return &BookingPolicy{
+		RestaurantID:              p.RestaurantID,
+		MinDurationMinutes:        int(p.MinDuration.Minutes()),
+		MaxDurationMinutes:        int(p.MaxDuration.Minutes()),
+		SlotGranularityMinutes:    int(p.SlotGranularity.Minutes()),
+		LeadTimeMinutes:           int(p.LeadTime.Minutes()),
+		BookingHorizonMinutes:     int(p.BookingHorizon.Minutes()),
+		MaxTablesPerBooking:       p.MaxTablesPerBooking,
+		MaxPartySize:              p.MaxPartySize,
+		TurnoverBufferMinutes:     int(p.TurnoverBuffer.Minutes()),
+		EventMinDurationMinutes:   int(p.EventMinDuration.Minutes()),
+		EventMaxDurationMinutes:   int(p.EventMaxDuration.Minutes()),
+		DepositPartySize:          p.DepositPartySize,
+		DepositPerGuest:           p.DepositPerGuest,
+		PeakStartMinute:           int(p.PeakStart.Minutes()),
+		PeakEndMinute:             int(p.PeakEnd.Minutes()),
+		PaymentHoldMinutes:        int(p.PaymentHold.Minutes()),
+		RefundWindowMinutes:       int(p.RefundWindow.Minutes()),
+		CancellationCutoffMinutes: int(p.CancellationCutoff.Minutes()),
+		MaxActiveBookings:         p.MaxActiveBookings,
+		ApprovalHoldMinutes:       int(p.ApprovalHold.Minutes()),
+		UpdatedAt:                 time.Now(),
+	}
+}
+
+// ConvertRestaurantStaffToModel конвертирует доменный объект RestaurantStaff в модель RestaurantStaff.
+func ConvertRestaurantStaffToModel(s *domain.RestaurantStaff) *RestaurantStaff {
+	return &RestaurantStaff{
+		RestaurantID: s.RestaurantID,
+		UserID:       s.UserID,
+		Role:         s.Role,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// ConvertOpeningHoursToDomain конвертирует модель OpeningHours в доменный объект OpeningHours.
+func ConvertOpeningHoursToDomain(h *OpeningHours) *domain.OpeningHours {
+	return &domain.OpeningHours{
+		RestaurantID: h.RestaurantID,
+		Weekday:      time.Weekday(h.Weekday),
+		Open:         minutes(h.OpenMinute),
+		Close:        minutes(h.CloseMinute),
+	}
+}
+
+// ConvertOpeningHoursToModel конвертирует доменный объект OpeningHours в модель OpeningHours.
+func ConvertOpeningHoursToModel(h *domain.OpeningHours) *OpeningHours {
+	return &OpeningHours{
+		RestaurantID: h.RestaurantID,
+		Weekday:      int(h.Weekday),
+		OpenMinute:   int(h.Open.Minutes()),
+		CloseMinute:  int(h.Close.Minutes()),
+	}
+}
+
+// ConvertSpecialDayToDomain конвертирует модель SpecialDay в доменный объект SpecialDay.
+func ConvertSpecialDayToDomain(d *SpecialDay) *domain.SpecialDay {
+	return &domain.SpecialDay{
+		RestaurantID: d.RestaurantID,
+		Date:         time.Date(d.Date.Year(), d.Date.Month(), d.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Closed:       d.Closed,
+		Open:         minutes(d.OpenMinute),
+		Close:        minutes(d.CloseMinute),
+		Note:         d.Note,
+	}
+}
+
+// ConvertSpecialDayToModel конвертирует доменный объект SpecialDay в модель SpecialDay.
+func ConvertSpecialDayToModel(d *domain.SpecialDay) *SpecialDay {
+	return &SpecialDay{
+		RestaurantID: d.RestaurantID,
+		Date:         time.Date(d.Date.Year(), d.Date.Month(), d.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Closed:       d.Closed,
+		OpenMinute:   int(d.Open.Minutes()),
+		CloseMinute:  int(d.Close.Minutes()),
+		Note:         d.Note,
+	}
+}
+
+// ConvertClosureToDomain конвертирует модель Closure в доменный объект Closure.
+func ConvertClosureToDomain(c *Closure) *domain.Closure {
+	return &domain.Closure{
+		ID:           c.ID,
+		RestaurantID: c.RestaurantID,
+		StartTime:    c.StartTime,
+		EndTime:      c.EndTime,
+		Reason:       c.Reason,
+	}
+}
+
+// ConvertClosureToModel конвертирует доменный объект Closure в модель Closure.
+func ConvertClosureToModel(c *domain.Closure) *Closure {
+	return &Closure{
+		ID:           c.ID,
+		RestaurantID: c.RestaurantID,
+		StartTime:    c.StartTime,
+		EndTime:      c.EndTime,
+		Reason:       c.Reason,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// ConvertTableBlockToDomain конвертирует модель TableBlock в доменный объект TableBlock.
+func ConvertTableBlockToDomain(b *TableBlock) *domain.TableBlock {
+	return &domain.TableBlock{
+		ID:           b.ID,
+		TableID:      b.TableID,
+		RestaurantID: b.RestaurantID,
+		StartTime:    b.StartTime,
+		EndTime:      b.EndTime,
+		Reason:       b.Reason,
+		CreatedBy:    b.CreatedBy,
+		CreatedAt:    b.CreatedAt,
+	}
+}
+
+// ConvertTableBlockToModel конвертирует доменный объект TableBlock в модель TableBlock.
+func ConvertTableBlockToModel(b *domain.TableBlock) *TableBlock {
+	return &TableBlock{
+		ID:           b.ID,
+		TableID:      b.TableID,
+		RestaurantID: b.RestaurantID,
+		StartTime:    b.StartTime,
+		EndTime:      b.EndTime,
+		Reason:       b.Reason,
+		CreatedBy:    b.CreatedBy,
+		CreatedAt:    b.CreatedAt,
+	}
+}
+
+// ConvertWaitlistEntryToDomain конвертирует модель WaitlistEntry в доменный объект WaitlistEntry.
+func ConvertWaitlistEntryToDomain(e *WaitlistEntry) *domain.WaitlistEntry {
+	entry := &domain.WaitlistEntry{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		RestaurantID: e.RestaurantID,
+		StartTime:    e.StartTime,
+		EndTime:      e.EndTime,
+		Capacity:     e.Capacity,
+		Contacts: domain.Contacts{
+			Name:  e.Contacts.Name,
+			Phone: e.Contacts.Phone,
+		},
+		Status:        e.Status,
+		OfferedTables: e.OfferedTables,
+		ReservationID: e.ReservationID,
+		CreatedAt:     e.CreatedAt,
+	}
+	if e.OfferExpiresAt != nil {
+		entry.OfferExpiresAt = *e.OfferExpiresAt
+	}
+	return entry
+}
+
+// ConvertWaitlistEntryToModel конвертирует доменный объект WaitlistEntry в модель WaitlistEntry.
+func ConvertWaitlistEntryToModel(e *domain.WaitlistEntry) *WaitlistEntry {
+	entry := &WaitlistEntry{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		RestaurantID: e.RestaurantID,
+		StartTime:    e.StartTime,
+		EndTime:      e.EndTime,
+		Capacity:     e.Capacity,
+		Contacts: Contact{
+			Name:  e.Contacts.Name,
+			Phone: e.Contacts.Phone,
+		},
+		Status:        e.Status,
+		OfferedTables: e.OfferedTables,
+		ReservationID: e.ReservationID,
+		CreatedAt:     e.CreatedAt,
+	}
+	if !e.OfferExpiresAt.IsZero() {
+		expiresAt := e.OfferExpiresAt
+		entry.OfferExpiresAt = &expiresAt
+	}
+	return entry
+}
+
+// ConvertReservationSeriesToDomain конвертирует модель ReservationSeries в доменный объект ReservationSeries.
+func ConvertReservationSeriesToDomain(s *ReservationSeries) *domain.ReservationSeries {
+	series := &domain.ReservationSeries{
+		ID:           s.ID,
+		UserID:       s.UserID,
+		RestaurantID: s.RestaurantID,
+		Rule: domain.RecurrenceRule{
+			Frequency: s.Frequency,
+			Count:     s.Count,
+		},
+		CreatedAt: s.CreatedAt,
+	}
+	if s.Until != nil {
+		series.Rule.Until = *s.Until
+	}
+	return series
+}
+
+// ConvertReservationSeriesToModel конвертирует доменный объект ReservationSeries в модель ReservationSeries.
+func ConvertReservationSeriesToModel(s *domain.ReservationSeries) *ReservationSeries {
+	series := &ReservationSeries{
+		ID:           s.ID,
+		UserID:       s.UserID,
+		RestaurantID: s.RestaurantID,
+		Frequency:    s.Rule.Frequency,
+		Count:        s.Rule.Count,
+		CreatedAt:    s.CreatedAt,
+	}
+	if !s.Rule.Until.IsZero() {
+		until := s.Rule.Until
+		series.Until = &until
+	}
+	return series
+}
+
+// ConvertZoneToDomain конвертирует модель Zone в доменный объект Zone.
+func ConvertZoneToDomain(z *Zone) *domain.Zone {
+	zone := &domain.Zone{
+		ID:               z.ID,
+		RestaurantID:     z.RestaurantID,
+		Name:             z.Name,
+		TableIDs:         make([]string, 0, len(z.Tables)),
+		MinDuration:      minutes(z.MinDurationMinutes),
+		MaxDuration:      minutes(z.MaxDurationMinutes),
+		RequiresApproval: z.RequiresApproval,
+	}
+	for _, t := range z.Tables {
+		zone.TableIDs = append(zone.TableIDs, t.TableID)
+	}
+	return zone
+}
+
+// ConvertZoneToModel конвертирует доменный объект Zone в модель Zone со списком столиков.
+func ConvertZoneToModel(z *domain.Zone) *Zone {
+	zone := &Zone{
+		ID:                 z.ID,
+		RestaurantID:       z.RestaurantID,
+		Name:               z.Name,
+		MinDurationMinutes: int(z.MinDuration.Minutes()),
+		MaxDurationMinutes: int(z.MaxDuration.Minutes()),
+		RequiresApproval:   z.RequiresApproval,
+		CreatedAt:          time.Now(),
+		Tables:             make([]ZoneTable, 0, len(z.TableIDs)),
+	}
+	for _, tableID := range z.TableIDs {
+		zone.Tables = append(zone.Tables, ZoneTable{ZoneID: z.ID, TableID: tableID})
+	}
+	return zone
+}
+
+// ConvertPaymentToDomain конвертирует модель Payment в доменный объект Payment.
+func ConvertPaymentToDomain(p *Payment) *domain.Payment {
+	return &domain.Payment{
+		ID:              p.ID,
+		ReservationID:   p.ReservationID,
+		Provider:        p.Provider,
+		ExternalID:      p.ExternalID,
+		Amount:          p.Amount,
+		Currency:        p.Currency,
+		Status:          p.Status,
+		ConfirmationURL: p.ConfirmationURL,
+		ExpiresAt:       p.ExpiresAt,
+		CreatedAt:       p.CreatedAt,
+	}
+}
+
+// ConvertPaymentToModel конвертирует доменный объект Payment в модель Payment.
+func ConvertPaymentToModel(p *domain.Payment) *Payment {
+	return &Payment{
+		ID:              p.ID,
+		ReservationID:   p.ReservationID,
+		Provider:        p.Provider,
+		ExternalID:      p.ExternalID,
+		Amount:          p.Amount,
+		Currency:        p.Currency,
+		Status:          p.Status,
+		ConfirmationURL: p.ConfirmationURL,
+		ExpiresAt:       p.ExpiresAt,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// ConvertIdempotencyKeyToDomain конвертирует модель IdempotencyKey в доменный объект IdempotencyRecord.
+func ConvertIdempotencyKeyToDomain(k *IdempotencyKey) *domain.IdempotencyRecord {
+	return &domain.IdempotencyRecord{
+		UserID:      k.UserID,
+		Key:         k.Key,
+		Fingerprint: k.Fingerprint,
+		Response:    k.Response,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+	}
+}
+
+// ConvertIdempotencyKeyToModel конвертирует доменный объект IdempotencyRecord в модель IdempotencyKey.
+func ConvertIdempotencyKeyToModel(r *domain.IdempotencyRecord) *IdempotencyKey {
+	return &IdempotencyKey{
+		UserID:      r.UserID,
+		Key:         r.Key,
+		Fingerprint: r.Fingerprint,
+		Response:    r.Response,
+		CreatedAt:   r.CreatedAt,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
+
+// ConvertAuditEntryToDomain конвертирует модель AuditEntry в доменный объект AuditEntry.
+func ConvertAuditEntryToDomain(e *AuditEntry) *domain.AuditEntry {
+	changes := make(map[string]domain.AuditChange, len(e.Changes))
+	for field, c := range e.Changes {
+		changes[field] = domain.AuditChange{From: c.From, To: c.To}
+	}
+	return &domain.AuditEntry{
+		ID:           e.ID,
+		RestaurantID: e.RestaurantID,
+		EntityType:   e.EntityType,
+		EntityID:     e.EntityID,
+		Action:       e.Action,
+		ActorID:      e.ActorID,
+		RequestID:    e.RequestID,
+		Changes:      changes,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+// ConvertAuditEntryToModel конвертирует доменный объект AuditEntry в модель AuditEntry.
+func ConvertAuditEntryToModel(e *domain.AuditEntry) *AuditEntry {
+	changes := make(AuditChanges, len(e.Changes))
+	for field, c := range e.Changes {
+		changes[field] = AuditChange{From: c.From, To: c.To}
+	}
+	return &AuditEntry{
+		ID:           e.ID,
+		RestaurantID: e.RestaurantID,
+		EntityType:   e.EntityType,
+		EntityID:     e.EntityID,
+		Action:       e.Action,
+		ActorID:      e.ActorID,
+		RequestID:    e.RequestID,
+		Changes:      changes,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+func minutes(m int) time.Duration {
+	return time.Duration(m) * time.Minute
+}
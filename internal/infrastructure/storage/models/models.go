@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"gorm.io/gorm"
 	"time"
 )
 
@@ -18,40 +19,55 @@ type User struct {
 
 // Restaurant представляет модель ресторана.
 type Restaurant struct {
-	ID        string    `gorm:"primaryKey"`
-	Name      string    `gorm:"size:255;not null"`
-	Address   string    `gorm:"size:255;not null"`
-	Phone     string    `gorm:"size:15"`
-	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-	Tables    []Table   `gorm:"foreignKey:RestaurantID"`
+	ID        string         `gorm:"primaryKey"`
+	Name      string         `gorm:"size:255;not null"`
+	Address   string         `gorm:"size:255;not null"`
+	Phone     string         `gorm:"size:15"`
+	Timezone  string         `gorm:"size:64;not null;default:UTC"`
+	CreatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Tables    []Table        `gorm:"foreignKey:RestaurantID"`
 }
 
 // Table представляет модель столика.
 type Table struct {
-	ID           string    `gorm:"primaryKey"`
-	RestaurantID string    `gorm:"not null"`
-	TableNumber  int       `gorm:"not null"`
-	Capacity     int       `gorm:"not null"`
-	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-	PositionX    float64   `gorm:"not null"`
-	PositionY    float64   `gorm:"not null"`
-	PositionZ    float64   `gorm:"not null"`
+	ID           string         `gorm:"primaryKey"`
+	RestaurantID string         `gorm:"not null"`
+	TableNumber  int            `gorm:"not null"`
+	Capacity     int            `gorm:"not null"`
+	CreatedAt    time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	PositionX    float64        `gorm:"not null"`
+	PositionY    float64        `gorm:"not null"`
+	PositionZ    float64        `gorm:"not null"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
 
 // Reservation представляет модель бронирования.
 type Reservation struct {
-	ID           string     `gorm:"primaryKey"`
-	UserID       string     `gorm:"not null"`
-	RestaurantID string     `gorm:"not null"`
-	StartTime    time.Time  `gorm:"not null"`
-	EndTime      time.Time  `gorm:"not null"`
-	Status       string     `gorm:"size:50;not null;check:status IN ('wait', 'sucess', 'canceled')"`
-	CreatedAt    time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
-	User         User       `gorm:"foreignKey:UserID"`
-	Restaurant   Restaurant `gorm:"foreignKey:RestaurantID"`
-	Capacity     int        `gorm:"not null"`
-	Tables       []Table    `gorm:"many2many:reservation_tables;"`
-	Contacts     Contact    `gorm:"type:jsonb"`
+	ID                string `gorm:"primaryKey"`
+	UserID            *string
+	RestaurantID      string    `gorm:"not null"`
+	StartTime         time.Time `gorm:"not null"`
+	EndTime           time.Time `gorm:"not null"`
+	Status            string    `gorm:"size:50;not null;check:status IN ('wait', 'sucess', 'canceled', 'pending_payment')"`
+	Source            string    `gorm:"size:20;not null;default:online;check:source IN ('online', 'walk_in')"`
+	SeatedAt          *time.Time
+	LeftAt            *time.Time
+	SeriesID          *string `gorm:"index"`
+	Scope             string  `gorm:"size:20;not null;default:tables;check:scope IN ('tables', 'zone', 'venue')"`
+	ZoneID            *string
+	CanceledAt        *time.Time
+	LateCancel        bool  `gorm:"not null;default:false"`
+	NoShow            bool  `gorm:"not null;default:false"`
+	Version           int64 `gorm:"not null;default:1"`
+	ApprovalExpiresAt *time.Time
+	CreatedAt         time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	User              User           `gorm:"foreignKey:UserID"`
+	Restaurant        Restaurant     `gorm:"foreignKey:RestaurantID"`
+	Capacity          int            `gorm:"not null"`
+	Tables            []Table        `gorm:"many2many:reservation_tables;"`
+	Contacts          Contact        `gorm:"type:jsonb"`
 }
 
 type Contact struct {
@@ -76,6 +92,21 @@ func (c Contact) Value() (driver.Value, error) {
 	return json.Marshal(c)
 }
 
+// ReservationSeries представляет модель серии повторяющихся броней.
+type ReservationSeries struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string `gorm:"not null;index"`
+	RestaurantID string `gorm:"not null"`
+	Frequency    string `gorm:"size:20;not null;check:frequency IN ('weekly', 'biweekly', 'monthly')"`
+	Until        *time.Time
+	Count        int       `gorm:"not null;default:0"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (ReservationSeries) TableName() string {
+	return "reservation_series"
+}
+
 // ReservationTable представляет связь между бронированием и столиком.
 type ReservationTable struct {
 	ID            string    `gorm:"primaryKey"`
@@ -83,3 +114,220 @@ type ReservationTable struct {
 	TableID       string    `gorm:"not null"`
 	CreatedAt     time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 }
+
+// BookingPolicy представляет модель правил бронирования ресторана.
+// Длительности хранятся в минутах, 0 — ограничение не задано.
+type BookingPolicy struct {
+	RestaurantID              string    `gorm:"primaryKey"`
+	MinDurationMinutes        int       `gorm:"not null;default:0"`
+	MaxDurationMinutes        int       `gorm:"not null;default:0"`
+	SlotGranularityMinutes    int       `gorm:"not null;default:0"`
+	LeadTimeMinutes           int       `gorm:"not null;default:0"`
+	BookingHorizonMinutes     int       `gorm:"not null;default:0"`
+	MaxTablesPerBooking       int       `gorm:"not null;default:0"`
+	MaxPartySize              int       `gorm:"not null;default:0"`
+	TurnoverBufferMinutes     int       `gorm:"not null;default:0"`
+	EventMinDurationMinutes   int       `gorm:"not null;default:0"`
+	EventMaxDurationMinutes   int       `gorm:"not null;default:0"`
+	DepositPartySize          int       `gorm:"not null;default:0"`
+	DepositPerGuest           int64     `gorm:"not null;default:0"`
+	PeakStartMinute           int       `gorm:"not null;default:0"`
+	PeakEndMinute             int       `gorm:"not null;default:0"`
+	PaymentHoldMinutes        int       `gorm:"not null;default:0"`
+	RefundWindowMinutes       int       `gorm:"not null;default:0"`
+	CancellationCutoffMinutes int       `gorm:"not null;default:0"`
+	MaxActiveBookings         int       `gorm:"not null;default:0"`
+	ApprovalHoldMinutes       int       `gorm:"not null;default:0"`
+	UpdatedAt                 time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// RestaurantStaff представляет сотрудника ресторана.
+type RestaurantStaff struct {
+	RestaurantID string    `gorm:"primaryKey"`
+	UserID       string    `gorm:"primaryKey"`
+	Role         string    `gorm:"size:50;not null;check:role IN ('manager', 'host')"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (RestaurantStaff) TableName() string {
+	return "restaurant_staff"
+}
+
+// Zone представляет модель зоны ресторана.
+type Zone struct {
+	ID                 string      `gorm:"primaryKey"`
+	RestaurantID       string      `gorm:"not null;index"`
+	Name               string      `gorm:"size:255;not null"`
+	MinDurationMinutes int         `gorm:"not null;default:0"`
+	MaxDurationMinutes int         `gorm:"not null;default:0"`
+	RequiresApproval   bool        `gorm:"not null;default:false"`
+	CreatedAt          time.Time   `gorm:"default:CURRENT_TIMESTAMP"`
+	Tables             []ZoneTable `gorm:"foreignKey:ZoneID"`
+}
+
+// ZoneTable представляет вхождение столика в зону.
+type ZoneTable struct {
+	ZoneID  string `gorm:"primaryKey"`
+	TableID string `gorm:"primaryKey"`
+}
+
+// OpeningHours представляет модель интервала работы ресторана в день недели.
+// Время хранится в минутах от полуночи.
+type OpeningHours struct {
+	RestaurantID string `gorm:"primaryKey"`
+	Weekday      int    `gorm:"primaryKey;check:weekday BETWEEN 0 AND 6"`
+	OpenMinute   int    `gorm:"primaryKey"`
+	CloseMinute  int    `gorm:"not null"`
+}
+
+// SpecialDay представляет модель переопределения расписания на дату.
+type SpecialDay struct {
+	RestaurantID string    `gorm:"primaryKey"`
+	Date         time.Time `gorm:"primaryKey;type:date"`
+	Closed       bool      `gorm:"not null;default:false"`
+	OpenMinute   int       `gorm:"not null;default:0"`
+	CloseMinute  int       `gorm:"not null;default:0"`
+	Note         string    `gorm:"size:255"`
+}
+
+// Closure представляет модель внепланового закрытия ресторана.
+type Closure struct {
+	ID           string    `gorm:"primaryKey"`
+	RestaurantID string    `gorm:"not null;index"`
+	StartTime    time.Time `gorm:"not null"`
+	EndTime      time.Time `gorm:"not null"`
+	Reason       string    `gorm:"size:255"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableBlock представляет модель блокировки столика.
+type TableBlock struct {
+	ID           string    `gorm:"primaryKey"`
+	TableID      string    `gorm:"not null;index"`
+	RestaurantID string    `gorm:"not null;index"`
+	StartTime    time.Time `gorm:"not null"`
+	EndTime      time.Time `gorm:"not null"`
+	Reason       string    `gorm:"size:255;not null"`
+	CreatedBy    string    `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// WaitlistEntry представляет модель записи в листе ожидания.
+type WaitlistEntry struct {
+	ID             string    `gorm:"primaryKey"`
+	UserID         string    `gorm:"not null;index"`
+	RestaurantID   string    `gorm:"not null"`
+	StartTime      time.Time `gorm:"not null"`
+	EndTime        time.Time `gorm:"not null"`
+	Capacity       int       `gorm:"not null"`
+	Contacts       Contact   `gorm:"type:jsonb"`
+	Status         string    `gorm:"size:50;not null;check:status IN ('waiting', 'offered', 'claimed', 'expired', 'canceled')"`
+	OfferedTables  TableIDs  `gorm:"type:jsonb"`
+	OfferExpiresAt *time.Time
+	ReservationID  string
+	CreatedAt      time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableIDs — список ID столиков, хранящийся в jsonb.
+type TableIDs []string
+
+// Scan реализует интерфейс sql.Scanner
+func (t *TableIDs) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan TableIDs: expected []byte, got %T", value)
+	}
+	return json.Unmarshal(bytes, t)
+}
+
+// Value реализует интерфейс driver.Valuer
+func (t TableIDs) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(t)
+}
+
+// Payment представляет модель депозита за бронь.
+type Payment struct {
+	ID              string    `gorm:"primaryKey"`
+	ReservationID   string    `gorm:"not null;index"`
+	Provider        string    `gorm:"size:50;not null"`
+	ExternalID      string    `gorm:"size:255;not null"`
+	Amount          int64     `gorm:"not null"`
+	Currency        string    `gorm:"size:3;not null"`
+	Status          string    `gorm:"size:20;not null;check:status IN ('pending', 'paid', 'failed', 'expired', 'canceled', 'refunded')"`
+	ConfirmationURL string    `gorm:"size:1024"`
+	ExpiresAt       time.Time `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// IdempotencyKey представляет модель ключа идемпотентности запроса гостя.
+type IdempotencyKey struct {
+	UserID      string    `gorm:"primaryKey"`
+	Key         string    `gorm:"primaryKey;size:255"`
+	Fingerprint string    `gorm:"size:64;not null"`
+	Response    []byte    `gorm:"type:bytea"`
+	CreatedAt   time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+// RateLimitBucket представляет модель корзины токенов ограничителя частоты запросов.
+type RateLimitBucket struct {
+	Key       string    `gorm:"primaryKey;size:255"`
+	Tokens    float64   `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+	FullAt    time.Time `gorm:"not null;index"`
+}
+
+// AuditEntry представляет модель записи журнала аудита.
+type AuditEntry struct {
+	ID           string       `gorm:"primaryKey"`
+	RestaurantID string       `gorm:"not null"`
+	EntityType   string       `gorm:"size:20;not null"`
+	EntityID     string       `gorm:"not null"`
+	Action       string       `gorm:"size:50;not null"`
+	ActorID      string       `gorm:"not null"`
+	RequestID    string       `gorm:"size:128"`
+	Changes      AuditChanges `gorm:"type:jsonb;not null"`
+	CreatedAt    time.Time    `gorm:"not null"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
+
+// AuditChange — значение поля до и после изменения.
+type AuditChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// AuditChanges — изменившиеся поля записи аудита, хранящиеся в jsonb.
+type AuditChanges map[string]AuditChange
+
+// Scan реализует интерфейс sql.Scanner
+func (c *AuditChanges) Scan(value interface{}) error {
+	if value == nil {
+		*c = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan AuditChanges: expected []byte, got %T", value)
+	}
+	return json.Unmarshal(bytes, c)
+}
+
+// Value реализует интерфейс driver.Valuer
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c)
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// CreatePayment сохраняет платеж за бронь.
+func (s *Storage) CreatePayment(ctx context.Context, payment *domain.Payment) error {
+	ctx, span := tracer.Start(ctx, "Storage.CreatePayment")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Create(models.ConvertPaymentToModel(payment)).Error
+}
+
+// GetPaymentByExternalID возвращает платеж по его ID у провайдера.
+func (s *Storage) GetPaymentByExternalID(ctx context.Context, provider string, externalID string) (*domain.Payment, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetPaymentByExternalID")
+	defer span.End()
+
+	var payment models.Payment
+	result := s.Database.WithContext(ctx).First(&payment, "provider = ? AND external_id = ?", provider, externalID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPaymentNotFound
+		}
+		return nil, result.Error
+	}
+	return models.ConvertPaymentToDomain(&payment), nil
+}
+
+// GetReservationPayment возвращает последний платеж брони или nil, если депозит не требовался.
+func (s *Storage) GetReservationPayment(ctx context.Context, reservationID string) (*domain.Payment, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetReservationPayment")
+	defer span.End()
+
+	var payments []models.Payment
+	err := s.Database.WithContext(ctx).Where("reservation_id = ?", reservationID).
+		Order("created_at DESC").Limit(1).Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 {
+		return nil, nil
+	}
+	return models.ConvertPaymentToDomain(&payments[0]), nil
+}
+
+// UpdatePaymentStatus меняет статус платежа, только если текущий статус равен from.
+func (s *Storage) UpdatePaymentStatus(ctx context.Context, id string, from, to string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.UpdatePaymentStatus")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).Model(&models.Payment{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{"status": to, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetExpiredPayments возвращает неоплаченные платежи, срок оплаты которых истек к now.
+func (s *Storage) GetExpiredPayments(ctx context.Context, now time.Time) ([]domain.Payment, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetExpiredPayments")
+	defer span.End()
+
+	var payments []models.Payment
+	err := s.Database.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", domain.PaymentPending, now).
+		Order("expires_at").
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Payment, 0, len(payments))
+	for i := range payments {
+		result = append(result, *models.ConvertPaymentToDomain(&payments[i]))
+	}
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetBookingPolicy возвращает правила бронирования ресторана или nil, если они не заданы.
+func (s *Storage) GetBookingPolicy(ctx context.Context, restaurantID string) (*domain.BookingPolicy, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetBookingPolicy")
+	defer span.End()
+
+	var policy models.BookingPolicy
+	result := s.Database.WithContext(ctx).First(&policy, "restaurant_id = ?", restaurantID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return models.ConvertBookingPolicyToDomain(&policy), nil
+}
+
+// SaveBookingPolicy создает или заменяет правила бронирования ресторана.
+func (s *Storage) SaveBookingPolicy(ctx context.Context, policy *domain.BookingPolicy) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveBookingPolicy")
+	defer span.End()
+
+	dbPolicy := models.ConvertBookingPolicyToModel(policy)
+	return s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "restaurant_id"}},
+		UpdateAll: true,
+	}).Create(dbPolicy).Error
+}
+
+// GetStaffRole возвращает роль пользователя в ресторане или пустую строку, если он там не работает.
+func (s *Storage) GetStaffRole(ctx context.Context, restaurantID string, userID string) (string, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetStaffRole")
+	defer span.End()
+
+	var staff models.RestaurantStaff
+	result := s.Database.WithContext(ctx).First(&staff, "restaurant_id = ? AND user_id = ?", restaurantID, userID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", result.Error
+	}
+	return staff.Role, nil
+}
+
+// SaveStaff назначает пользователя сотрудником ресторана или меняет его роль.
+func (s *Storage) SaveStaff(ctx context.Context, staff *domain.RestaurantStaff) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveStaff")
+	defer span.End()
+
+	dbStaff := models.ConvertRestaurantStaffToModel(staff)
+	return s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "restaurant_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(dbStaff).Error
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// RateLimiter хранит корзины токенов в Postgres, поэтому ограничение общее для всех реплик сервиса.
+type RateLimiter struct {
+	db *gorm.DB
+}
+
+func NewRateLimiter(db *gorm.DB) *RateLimiter {
+	return &RateLimiter{db: db}
+}
+
+// Allow забирает токен из корзины key. Строка корзины блокируется на время транзакции,
+// поэтому параллельные запросы одного клиента не могут потратить один токен дважды.
+func (r *RateLimiter) Allow(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error) {
+	ctx, span := tracer.Start(ctx, "RateLimiter.Allow")
+	defer span.End()
+
+	now := time.Now()
+	var allowed bool
+	var retryAfter time.Duration
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RateLimitBucket{
+			Key:       key,
+			Tokens:    float64(limit.Burst),
+			UpdatedAt: now,
+			FullAt:    now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		var bucket models.RateLimitBucket
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&bucket, "key = ?", key).Error; err != nil {
+			return err
+		}
+		var next domain.TokenBucket
+		next, allowed, retryAfter = limit.Take(domain.TokenBucket{Tokens: bucket.Tokens, UpdatedAt: bucket.UpdatedAt}, now)
+		if !allowed {
+			return nil
+		}
+		return tx.Model(&bucket).Updates(map[string]interface{}{
+			"tokens":     next.Tokens,
+			"updated_at": next.UpdatedAt,
+			"full_at":    limit.FullAt(next),
+		}).Error
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return allowed, retryAfter, nil
+}
+
+// Cleanup удаляет заполнившиеся корзины: они ничем не отличаются от новых.
+func (r *RateLimiter) Cleanup(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "RateLimiter.Cleanup")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Delete(&models.RateLimitBucket{}, "full_at <= ?", time.Now()).Error
+}
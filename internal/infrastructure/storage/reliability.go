@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetGuestReliability подсчитывает, сколько раз гость пришел, не пришел или поздно отменил бронь.
+// Архивные брони тоже учитываются: при архивации у них стираются только контакты гостя.
+func (s *Storage) GetGuestReliability(ctx context.Context, userID string) (*domain.GuestReliability, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetGuestReliability")
+	defer span.End()
+
+	var counts struct {
+		Visits      int
+		NoShows     int
+		LateCancels int
+	}
+	err := s.Database.WithContext(ctx).Unscoped().Model(&models.Reservation{}).
+		Select("COUNT(*) FILTER (WHERE seated_at IS NOT NULL) AS visits, "+
+			"COUNT(*) FILTER (WHERE no_show) AS no_shows, "+
+			"COUNT(*) FILTER (WHERE late_cancel) AS late_cancels").
+		Where("user_id = ?", userID).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &domain.GuestReliability{
+		UserID:      userID,
+		Visits:      counts.Visits,
+		NoShows:     counts.NoShows,
+		LateCancels: counts.LateCancels,
+	}, nil
+}
+
+// HasGuestReservation проверяет, бронировал ли гость когда-либо столик в ресторане, включая архивные брони.
+func (s *Storage) HasGuestReservation(ctx context.Context, userID string, restaurantID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.HasGuestReservation")
+	defer span.End()
+
+	var count int64
+	err := s.Database.WithContext(ctx).Unscoped().Model(&models.Reservation{}).
+		Where("user_id = ? AND restaurant_id = ?", userID, restaurantID).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// checkActiveReservations блокирует брони гостя в ресторане до конца транзакции tx и проверяет,
+// что неотмененных и еще не закончившихся броней меньше maxActive.
+func checkActiveReservations(tx *gorm.DB, userID string, restaurantID string, maxActive int) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "reservations/"+userID+"/"+restaurantID).Error; err != nil {
+		return err
+	}
+	var count int64
+	err := tx.Model(&models.Reservation{}).
+		Where("user_id = ? AND restaurant_id = ? AND status <> ? AND end_time > ?", userID, restaurantID, domain.ReservationCanceled, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count >= int64(maxActive) {
+		return domain.ErrTooManyReservations.Withf("user already has %d active reservations in restaurant %s, limit is %d", count, restaurantID, maxActive)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// RescheduleReservation переносит бронь на новое время и набор столиков в одной транзакции.
+// Строки столиков блокируются, чтобы два переноса не заняли один столик одновременно.
+// Доступность проверяется с буфером buffer без учета самой брони; при конфликте
+// возвращается ErrTableNotAvailable и ничего не меняется. Если версия брони в базе
+// уже не равна reservation.Version, возвращается ErrReservationModified.
+func (s *Storage) RescheduleReservation(ctx context.Context, reservation *domain.Reservation, tableIDs map[string]string, buffer time.Duration) error {
+	ctx, span := tracer.Start(ctx, "Storage.RescheduleReservation")
+	defer span.End()
+
+	ids := make([]string, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		ids = append(ids, tableID)
+	}
+	err := s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []models.Table
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Order("id").Find(&locked).Error; err != nil {
+			return err
+		}
+		for _, tableID := range ids {
+			ok, err := tableAvailable(tx, tableID, reservation.StartTime.Add(-buffer), reservation.EndTime.Add(buffer), reservation.ID, "")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return domain.ErrTableNotAvailable.Withf("table %s not available", tableID)
+			}
+		}
+
+		result := tx.Model(&models.Reservation{}).
+			Where("id = ? AND status <> ? AND version = ?", reservation.ID, domain.ReservationCanceled, reservation.Version).
+			Updates(map[string]interface{}{
+				"start_time": reservation.StartTime,
+				"end_time":   reservation.EndTime,
+				"version":    reservation.Version + 1,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Бронь загружена сценарием перед переносом: раз условие не выполнилось,
+			// ее успели отменить или изменить параллельно.
+			return domain.ErrReservationModified
+		}
+
+		if err := tx.Where("reservation_id = ?", reservation.ID).Delete(&models.ReservationTable{}).Error; err != nil {
+			return err
+		}
+		for key, tableID := range tableIDs {
+			reservationTable := models.ReservationTable{
+				ID:            key,
+				ReservationID: reservation.ID,
+				TableID:       tableID,
+			}
+			if err := tx.Create(&reservationTable).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	reservation.Version++
+	return nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// DeleteTable выводит столик из зала: помечает его удаленным и убирает из зон. Брони, в которых
+// столик уже был, сохраняются. Возвращает удаленный столик или nil, если столик не найден.
+// Если у столика есть действующие брони, которые еще не закончились к now, возвращает ErrTableHasReservations.
+func (s *Storage) DeleteTable(ctx context.Context, restaurantID string, tableID string, now time.Time) (*domain.Table, error) {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteTable")
+	defer span.End()
+
+	var dbTables []models.Table
+	err := s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Блокируем столик, чтобы параллельная бронь не появилась между проверкой и удалением
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("restaurant_id = ? AND id = ?", restaurantID, tableID).
+			Find(&dbTables).Error
+		if err != nil || len(dbTables) == 0 {
+			return err
+		}
+
+		var upcoming int64
+		err = tx.Model(&models.ReservationTable{}).
+			Joins("JOIN reservations ON reservation_tables.reservation_id = reservations.id AND reservations.deleted_at IS NULL").
+			Where("reservation_tables.table_id = ? AND reservations.status <> ?", tableID, domain.ReservationCanceled).
+			Where(occupiedUntil+" > ?", now).
+			Count(&upcoming).Error
+		if err != nil {
+			return err
+		}
+		if upcoming > 0 {
+			return domain.ErrTableHasReservations.Withf("table %s has %d upcoming reservations", tableID, upcoming)
+		}
+
+		if err := tx.Where("table_id = ?", tableID).Delete(&models.ZoneTable{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&dbTables[0]).Error
+	})
+	if err != nil || len(dbTables) == 0 {
+		return nil, err
+	}
+	return models.ConvertTableToDomain(&dbTables[0]), nil
+}
+
+// ArchiveReservations архивирует брони, закончившиеся раньше before: стирает контакты гостя
+// и помечает бронь удаленной. Связь с пользователем остается, чтобы не терялась
+// история посещений и надежность гостя. Журнал аудита контактов не содержит: новые записи
+// пишутся без них, а старые обезличены миграцией 000017. Возвращает число архивированных броней.
+func (s *Storage) ArchiveReservations(ctx context.Context, before time.Time, now time.Time) (int64, error) {
+	ctx, span := tracer.Start(ctx, "Storage.ArchiveReservations")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).Model(&models.Reservation{}).
+		Where("end_time < ?", before).
+		Updates(map[string]interface{}{
+			"contacts":   models.Contact{},
+			"deleted_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// GetSchedule возвращает расписание ресторана: все недельные интервалы, особые дни
+// и закрытия, затрагивающие промежуток [from, to]. Особые дни берутся с запасом
+// в сутки с каждой стороны, чтобы учесть смены после полуночи и разницу часовых поясов.
+func (s *Storage) GetSchedule(ctx context.Context, restaurantID string, from, to time.Time) (*domain.Schedule, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetSchedule")
+	defer span.End()
+
+	db := s.Database.WithContext(ctx)
+	var hours []models.OpeningHours
+	if err := db.Where("restaurant_id = ?", restaurantID).Order("weekday, open_minute").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	var days []models.SpecialDay
+	if err := db.Where("restaurant_id = ? AND date BETWEEN ? AND ?", restaurantID,
+		from.AddDate(0, 0, -1).Format(time.DateOnly), to.AddDate(0, 0, 1).Format(time.DateOnly)).
+		Order("date").Find(&days).Error; err != nil {
+		return nil, err
+	}
+	var closures []models.Closure
+	if err := db.Where("restaurant_id = ? AND start_time < ? AND end_time > ?", restaurantID, to, from).
+		Order("start_time").Find(&closures).Error; err != nil {
+		return nil, err
+	}
+
+	schedule := &domain.Schedule{
+		Weekly:      make([]domain.OpeningHours, 0, len(hours)),
+		SpecialDays: make([]domain.SpecialDay, 0, len(days)),
+		Closures:    make([]domain.Closure, 0, len(closures)),
+	}
+	for _, h := range hours {
+		schedule.Weekly = append(schedule.Weekly, *models.ConvertOpeningHoursToDomain(&h))
+	}
+	for _, d := range days {
+		schedule.SpecialDays = append(schedule.SpecialDays, *models.ConvertSpecialDayToDomain(&d))
+	}
+	for _, c := range closures {
+		schedule.Closures = append(schedule.Closures, *models.ConvertClosureToDomain(&c))
+	}
+	return schedule, nil
+}
+
+// ReplaceOpeningHours заменяет недельное расписание ресторана целиком.
+func (s *Storage) ReplaceOpeningHours(ctx context.Context, restaurantID string, hours []domain.OpeningHours) error {
+	ctx, span := tracer.Start(ctx, "Storage.ReplaceOpeningHours")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ?", restaurantID).Delete(&models.OpeningHours{}).Error; err != nil {
+			return err
+		}
+		for _, h := range hours {
+			h.RestaurantID = restaurantID
+			if err := tx.Create(models.ConvertOpeningHoursToModel(&h)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveSpecialDay создает или заменяет особый день ресторана.
+func (s *Storage) SaveSpecialDay(ctx context.Context, day *domain.SpecialDay) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveSpecialDay")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "restaurant_id"}, {Name: "date"}},
+		UpdateAll: true,
+	}).Create(models.ConvertSpecialDayToModel(day)).Error
+}
+
+// DeleteSpecialDay удаляет особый день. Возвращает false, если такого дня не было.
+func (s *Storage) DeleteSpecialDay(ctx context.Context, restaurantID string, date time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteSpecialDay")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).
+		Where("restaurant_id = ? AND date = ?", restaurantID, date.Format(time.DateOnly)).
+		Delete(&models.SpecialDay{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CreateClosure сохраняет внеплановое закрытие ресторана.
+func (s *Storage) CreateClosure(ctx context.Context, closure *domain.Closure) error {
+	ctx, span := tracer.Start(ctx, "Storage.CreateClosure")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Create(models.ConvertClosureToModel(closure)).Error
+}
+
+// DeleteClosure удаляет закрытие ресторана и возвращает его. Возвращает nil, если закрытие не найдено.
+func (s *Storage) DeleteClosure(ctx context.Context, restaurantID string, closureID string) (*domain.Closure, error) {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteClosure")
+	defer span.End()
+
+	var closure models.Closure
+	result := s.Database.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("restaurant_id = ? AND id = ?", restaurantID, closureID).
+		Delete(&closure)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return models.ConvertClosureToDomain(&closure), nil
+}
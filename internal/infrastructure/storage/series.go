@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// CreateReservationSeries сохраняет серию повторяющихся броней.
+func (s *Storage) CreateReservationSeries(ctx context.Context, series *domain.ReservationSeries) error {
+	ctx, span := tracer.Start(ctx, "Storage.CreateReservationSeries")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Create(models.ConvertReservationSeriesToModel(series)).Error
+}
+
+// DeleteReservationSeries удаляет серию. Брони серии, если они есть, становятся разовыми.
+func (s *Storage) DeleteReservationSeries(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteReservationSeries")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Delete(&models.ReservationSeries{}, "id = ?", id).Error
+}
+
+// GetReservationSeries возвращает серию по ID.
+func (s *Storage) GetReservationSeries(ctx context.Context, id string) (*domain.ReservationSeries, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetReservationSeries")
+	defer span.End()
+
+	var series models.ReservationSeries
+	result := s.Database.WithContext(ctx).First(&series, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSeriesNotFound
+		}
+		return nil, result.Error
+	}
+	return models.ConvertReservationSeriesToDomain(&series), nil
+}
+
+// GetSeriesReservations возвращает брони серии в порядке времени.
+func (s *Storage) GetSeriesReservations(ctx context.Context, seriesID string) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetSeriesReservations")
+	defer span.End()
+
+	var dbReservations []models.Reservation
+	err := s.Database.WithContext(ctx).Where("series_id = ?", seriesID).Order("start_time").Find(&dbReservations).Error
+	if err != nil {
+		return nil, err
+	}
+	reservations := make([]*domain.Reservation, 0, len(dbReservations))
+	for i := range dbReservations {
+		reservations = append(reservations, models.ConvertReservationToDomain(&dbReservations[i]))
+	}
+	return reservations, nil
+}
+
+// CancelSeriesReservations отменяет действующие брони серии, начинающиеся не раньше from,
+// и возвращает отмененные брони. Уже начавшиеся и отмененные брони не меняются.
+// Брони, до начала которых осталось меньше cutoff, отмечаются как поздно отмененные.
+func (s *Storage) CancelSeriesReservations(ctx context.Context, seriesID string, from time.Time, cutoff time.Duration) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.CancelSeriesReservations")
+	defer span.End()
+
+	var dbReservations []models.Reservation
+	err := s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("series_id = ? AND status <> ? AND start_time >= ? AND seated_at IS NULL", seriesID, domain.ReservationCanceled, from).
+			Order("start_time").
+			Find(&dbReservations).Error
+		if err != nil || len(dbReservations) == 0 {
+			return err
+		}
+		ids := make([]string, 0, len(dbReservations))
+		for _, r := range dbReservations {
+			ids = append(ids, r.ID)
+		}
+		return tx.Model(&models.Reservation{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":      domain.ReservationCanceled,
+			"canceled_at": from,
+			"late_cancel": gorm.Expr("start_time < ?", from.Add(cutoff)),
+			"version":     gorm.Expr("version + 1"),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	reservations := make([]*domain.Reservation, 0, len(dbReservations))
+	for i := range dbReservations {
+		reservation := models.ConvertReservationToDomain(&dbReservations[i])
+		reservation.Status = domain.ReservationCanceled
+		reservation.CanceledAt = from
+		reservation.LateCancel = reservation.StartTime.Before(from.Add(cutoff))
+		reservation.Version++
+		reservations = append(reservations, reservation)
+	}
+	return reservations, nil
+}
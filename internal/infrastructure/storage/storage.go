@@ -3,12 +3,28 @@ package storage
 import (
 	"booking_system/internal/domain"
 	"booking_system/internal/infrastructure/storage/models"
+	"context"
 	"errors"
+	"go.opentelemetry.io/otel"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"log/slog"
 	"time"
 )
 
+var tracer = otel.Tracer("booking_system/storage")
+
+// restaurantDayCondition отбирает брони, начинающиеся в календарный день по часам ресторана.
+// Границы суток вычисляет Postgres, поэтому переходы на летнее время учитываются автоматически.
+// Ожидает дату в формате 2006-01-02 дважды.
+const restaurantDayCondition = "reservations.start_time >= (CAST(? AS date)::timestamp AT TIME ZONE restaurants.timezone) " +
+	"AND reservations.start_time < ((CAST(? AS date) + 1)::timestamp AT TIME ZONE restaurants.timezone)"
+
+// occupiedUntil — момент, до которого бронь занимает столики. Гости, которые засиделись
+// дольше брони, занимают столик до тех пор, пока хост не отметит их уход.
+const occupiedUntil = "CASE WHEN reservations.seated_at IS NOT NULL AND reservations.left_at IS NULL " +
+	"THEN GREATEST(reservations.end_time, now()) ELSE reservations.end_time END"
+
 type Storage struct {
 	logger   *slog.Logger
 	Database *gorm.DB
@@ -22,29 +38,63 @@ func New(logger *slog.Logger, db *gorm.DB) *Storage {
 }
 
 // GetTablesWithAvailability возвращает все столы с пометками о их доступности на конкретную дату и время.
-func (s *Storage) GetTablesWithAvailability(restaurantID string, dateTime time.Time) ([]domain.TableAvailability, error) {
+func (s *Storage) GetTablesWithAvailability(ctx context.Context, restaurantID string, dateTime time.Time) ([]domain.TableAvailability, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetTablesWithAvailability")
+	defer span.End()
 	var tables []models.Table
 	var reservations []models.Reservation
 
 	// Получаем все столы для конкретного ресторана
-	if err := s.Database.Where("restaurant_id = ?", restaurantID).Find(&tables).Error; err != nil {
+	if err := s.Database.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Find(&tables).Error; err != nil {
 		return nil, err
 	}
 
 	// Получаем все бронирования, которые пересекаются с указанной датой и временем
 	// И загружаем связанные таблицы (Tables) для каждого бронирования
-	if err := s.Database.
+	// Отмененные брони столик не занимают
+	if err := s.Database.WithContext(ctx).
 		Preload("Tables").
-		Where("restaurant_id = ? AND start_time <= ? AND end_time >= ?", restaurantID, dateTime, dateTime).
+		Where("restaurant_id = ? AND status <> ? AND start_time <= ? AND "+occupiedUntil+" >= ?",
+			restaurantID, domain.ReservationCanceled, dateTime, dateTime).
 		Find(&reservations).Error; err != nil {
 		return nil, err
 	}
 
+	// Получаем блокировки столиков, действующие в указанный момент
+	var blocks []models.TableBlock
+	if err := s.Database.WithContext(ctx).
+		Where("restaurant_id = ? AND start_time <= ? AND end_time > ?", restaurantID, dateTime, dateTime).
+		Find(&blocks).Error; err != nil {
+		return nil, err
+	}
+
 	// Создаем мапу для быстрого поиска занятых столов
 	occupiedTables := make(map[string]bool)
+	seatedTables := make(map[string]bool)
 	for _, reservation := range reservations {
+		seated := reservation.SeatedAt != nil && reservation.LeftAt == nil
 		for _, table := range reservation.Tables {
 			occupiedTables[table.ID] = true
+			if seated {
+				seatedTables[table.ID] = true
+			}
+		}
+	}
+	for _, block := range blocks {
+		occupiedTables[block.TableID] = true
+	}
+
+	// Столики, придержанные для гостей из листа ожидания
+	var offers []models.WaitlistEntry
+	if err := s.Database.WithContext(ctx).
+		Where("restaurant_id = ? AND status = ? AND offer_expires_at > ? AND start_time <= ? AND end_time > ?",
+			restaurantID, domain.WaitlistOffered, time.Now(), dateTime, dateTime).
+		Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	for _, offer := range offers {
+		for _, tableID := range offer.OfferedTables {
+			occupiedTables[tableID] = true
 		}
 	}
 
@@ -56,21 +106,24 @@ func (s *Storage) GetTablesWithAvailability(restaurantID string, dateTime time.T
 		result = append(result, domain.TableAvailability{
 			Table:       domainTable,
 			IsAvailable: isAvailable,
+			IsOccupied:  seatedTables[table.ID],
 		})
 	}
-	s.logger.Debug("Tables with availability: ", result)
+	s.logger.Debug("Tables with availability", "tables", result)
 
 	return result, nil
 }
 
 // GetTable возвращает столик по его ID.
-func (s *Storage) GetTable(tableId string) (*domain.Table, error) {
+func (s *Storage) GetTable(ctx context.Context, tableId string) (*domain.Table, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetTable")
+	defer span.End()
 	var table models.Table
-	result := s.Database.First(&table, "id = ?", tableId)
+	result := s.Database.WithContext(ctx).First(&table, "id = ?", tableId)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			s.logger.Info("Table not found", "tableId", tableId)
-			return nil, errors.New("table not found")
+			return nil, domain.ErrTableNotFound.Withf("table %s not found", tableId)
 		}
 		s.logger.Error("Failed to get table", "error", result.Error)
 		return nil, result.Error
@@ -79,9 +132,11 @@ func (s *Storage) GetTable(tableId string) (*domain.Table, error) {
 }
 
 // CheckUserForTelegram проверяет, существует ли пользователь с указанным Telegram ID.
-func (s *Storage) CheckUserForTelegram(telegramId int64) (bool, domain.User, error) {
+func (s *Storage) CheckUserForTelegram(ctx context.Context, telegramId int64) (bool, domain.User, error) {
+	ctx, span := tracer.Start(ctx, "Storage.CheckUserForTelegram")
+	defer span.End()
 	var user models.User
-	result := s.Database.First(&user, "telegram_id = ?", telegramId)
+	result := s.Database.WithContext(ctx).First(&user, "telegram_id = ?", telegramId)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			s.logger.Info("User not found", "telegramId", telegramId)
@@ -94,9 +149,11 @@ func (s *Storage) CheckUserForTelegram(telegramId int64) (bool, domain.User, err
 }
 
 // CreateUser создает нового пользователя.
-func (s *Storage) CreateUser(user domain.User) (domain.User, error) {
+func (s *Storage) CreateUser(ctx context.Context, user domain.User) (domain.User, error) {
+	ctx, span := tracer.Start(ctx, "Storage.CreateUser")
+	defer span.End()
 	userModel := models.ConvertUserToModel(&user)
-	result := s.Database.Create(userModel)
+	result := s.Database.WithContext(ctx).Create(userModel)
 	if result.Error != nil {
 		s.logger.Error("Failed to create user", "error", result.Error)
 		return domain.User{}, result.Error
@@ -106,16 +163,52 @@ func (s *Storage) CreateUser(user domain.User) (domain.User, error) {
 }
 
 // IsTableAvailable проверяет, свободен ли столик на указанное время.
-func (s *Storage) IsTableAvailable(tableID string, startTime, endTime time.Time) (bool, error) {
+func (s *Storage) IsTableAvailable(ctx context.Context, tableID string, startTime, endTime time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.IsTableAvailable")
+	defer span.End()
+
+	return tableAvailable(s.Database.WithContext(ctx), tableID, startTime, endTime, "", "")
+}
+
+// tableAvailable проверяет, что столик свободен в интервале. Бронь excludeReservationID
+// не учитывается, чтобы перенос брони не конфликтовал сам с собой, а предложение
+// excludeEntryID — чтобы гость мог занять придержанный для него столик.
+func tableAvailable(db *gorm.DB, tableID string, startTime, endTime time.Time, excludeReservationID string, excludeEntryID string) (bool, error) {
 	var count int64
 
 	// Проверяем, есть ли бронирования, которые пересекаются с запрашиваемым временем
-	err := s.Database.Model(&models.ReservationTable{}).
-		Joins("JOIN reservations ON reservation_tables.reservation_id = reservations.id").
-		Where("reservation_tables.table_id = ?", tableID).
-		Where("(? <= reservations.end_time) AND (? >= reservations.start_time)", startTime, endTime).
+	err := db.Model(&models.ReservationTable{}).
+		Joins("JOIN reservations ON reservation_tables.reservation_id = reservations.id AND reservations.deleted_at IS NULL").
+		Where("reservation_tables.table_id = ? AND reservations.status <> ?", tableID, domain.ReservationCanceled).
+		Where("reservations.id <> ?", excludeReservationID).
+		Where("(? <= "+occupiedUntil+") AND (? >= reservations.start_time)", startTime, endTime).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	// Блокировка столика занимает его так же, как бронь
+	err = db.Model(&models.TableBlock{}).
+		Where("table_id = ? AND start_time < ? AND end_time > ?", tableID, endTime, startTime).
 		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
 
+	// Столик, предложенный гостю из листа ожидания, придержан до истечения предложения
+	err = db.Model(&models.WaitlistEntry{}).
+		Where(heldTableCondition, time.Now(), tableID).
+		Where("id <> ?", excludeEntryID).
+		Where("start_time < ? AND end_time > ?", endTime, startTime).
+		Count(&count).Error
 	if err != nil {
 		return false, err
 	}
@@ -124,9 +217,11 @@ func (s *Storage) IsTableAvailable(tableID string, startTime, endTime time.Time)
 	return count == 0, nil
 }
 
-func (s *Storage) GetUserForId(user domain.User) (*domain.User, error) {
+func (s *Storage) GetUserForId(ctx context.Context, user domain.User) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetUserForId")
+	defer span.End()
 	var dbUser models.User
-	result := s.Database.First(&dbUser, user.ID)
+	result := s.Database.WithContext(ctx).First(&dbUser, "id = ?", user.ID)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil // Пользователь не найден
@@ -136,9 +231,11 @@ func (s *Storage) GetUserForId(user domain.User) (*domain.User, error) {
 	return models.ConvertUserToDomain(&dbUser), nil
 }
 
-func (s *Storage) GetUserReservationsUser(userId string) ([]*domain.Reservation, error) {
+func (s *Storage) GetUserReservationsUser(ctx context.Context, userId string) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetUserReservationsUser")
+	defer span.End()
 	var dbReservations []models.Reservation
-	result := s.Database.Preload("User").Preload("Restaurant").Preload("Tables").Where("user_id = ?", userId).Find(&dbReservations)
+	result := s.Database.WithContext(ctx).Preload("User").Preload("Restaurant").Preload("Tables").Where("user_id = ?", userId).Find(&dbReservations)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -150,13 +247,13 @@ func (s *Storage) GetUserReservationsUser(userId string) ([]*domain.Reservation,
 	return reservations, nil
 }
 
-func (s *Storage) GetUserReservationsUserForDate(date time.Time, userID string) ([]*domain.Reservation, error) {
+func (s *Storage) GetUserReservationsUserForDate(ctx context.Context, date time.Time, userID string) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetUserReservationsUserForDate")
+	defer span.End()
 	var dbReservations []models.Reservation
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.AddDate(0, 0, 1)
-
-	result := s.Database.Preload("User").Preload("Restaurant").Preload("Tables").
-		Where("start_time >= ? AND start_time < ? and user_id = ?", startOfDay, endOfDay, userID).
+	result := s.Database.WithContext(ctx).Preload("User").Preload("Restaurant").Preload("Tables").
+		Joins("JOIN restaurants ON restaurants.id = reservations.restaurant_id AND restaurants.deleted_at IS NULL").
+		Where(restaurantDayCondition+" AND reservations.user_id = ?", date.Format(time.DateOnly), date.Format(time.DateOnly), userID).
 		Find(&dbReservations)
 	if result.Error != nil {
 		return nil, result.Error
@@ -169,13 +266,13 @@ func (s *Storage) GetUserReservationsUserForDate(date time.Time, userID string)
 	return reservations, nil
 }
 
-func (s *Storage) GetReservationsForDate(date time.Time) ([]*domain.Reservation, error) {
+func (s *Storage) GetReservationsForDate(ctx context.Context, date time.Time) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetReservationsForDate")
+	defer span.End()
 	var dbReservations []models.Reservation
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.AddDate(0, 0, 1)
-
-	result := s.Database.Preload("User").Preload("Restaurant").Preload("Tables").
-		Where("start_time >= ? AND start_time < ?", startOfDay, endOfDay).
+	result := s.Database.WithContext(ctx).Preload("User").Preload("Restaurant").Preload("Tables").
+		Joins("JOIN restaurants ON restaurants.id = reservations.restaurant_id AND restaurants.deleted_at IS NULL").
+		Where(restaurantDayCondition, date.Format(time.DateOnly), date.Format(time.DateOnly)).
 		Find(&dbReservations)
 	if result.Error != nil {
 		return nil, result.Error
@@ -188,19 +285,61 @@ func (s *Storage) GetReservationsForDate(date time.Time) ([]*domain.Reservation,
 	return reservations, nil
 }
 
-func (s *Storage) UpdateReservation(reservation *domain.Reservation) (bool, error) {
+// UpdateReservation сохраняет бронь, только если ее версия в базе равна reservation.Version,
+// и увеличивает версию. Возвращает false, если бронь не найдена или ее уже изменил другой запрос.
+func (s *Storage) UpdateReservation(ctx context.Context, reservation *domain.Reservation) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.UpdateReservation")
+	defer span.End()
+
+	// Меняются только поля, которые сценарии правят у существующей брони. Ресторан, серия,
+	// зона, источник и пометка об удалении задаются при создании и здесь не перезаписываются.
 	dbReservation := models.ConvertReservationToModel(reservation)
-	result := s.Database.Save(dbReservation)
+	result := s.Database.WithContext(ctx).Model(&models.Reservation{}).
+		Where("id = ? AND version = ?", reservation.ID, reservation.Version).
+		Updates(map[string]interface{}{
+			"status":      dbReservation.Status,
+			"start_time":  dbReservation.StartTime,
+			"end_time":    dbReservation.EndTime,
+			"capacity":    dbReservation.Capacity,
+			"contacts":    dbReservation.Contacts,
+			"seated_at":   dbReservation.SeatedAt,
+			"left_at":     dbReservation.LeftAt,
+			"canceled_at": dbReservation.CanceledAt,
+			"late_cancel": dbReservation.LateCancel,
+			"no_show":     dbReservation.NoShow,
+			"version":     gorm.Expr("version + 1"),
+		})
 	if result.Error != nil {
 		return false, result.Error
 	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	reservation.Version++
 	return true, nil
 }
 
-func (s *Storage) GetReservationForId(id string) (*domain.Reservation, error) {
+// UpdateReservationStatus меняет статус брони, только если текущий статус равен from.
+// Возвращает false, если бронь не найдена или ее статус уже изменился.
+func (s *Storage) UpdateReservationStatus(ctx context.Context, id string, from, to string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.UpdateReservationStatus")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).Model(&models.Reservation{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{"status": to, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (s *Storage) GetReservationForId(ctx context.Context, id string) (*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetReservationForId")
+	defer span.End()
 	var dbReservation models.Reservation
 
-	result := s.Database.Preload("User").Preload("Restaurant").Preload("Tables").
+	result := s.Database.WithContext(ctx).Preload("User").Preload("Restaurant").Preload("Tables").
 		Where("id = ?", id).
 		First(&dbReservation)
 	if result.Error != nil {
@@ -209,22 +348,39 @@ func (s *Storage) GetReservationForId(id string) (*domain.Reservation, error) {
 		}
 		return nil, result.Error
 	}
-	s.logger.Debug("dbReservation: %v", dbReservation)
+	s.logger.Debug("dbReservation", "reservation", dbReservation)
 	return models.ConvertReservationToDomain(&dbReservation), nil
 }
 
-func (s *Storage) CreateReservation(reservation *domain.Reservation, tableIDs map[string]string) (string, error) {
+func (s *Storage) CreateReservation(ctx context.Context, reservation *domain.Reservation, tableIDs map[string]string, maxActive int) (string, error) {
+	ctx, span := tracer.Start(ctx, "Storage.CreateReservation")
+	defer span.End()
 
-	dbReservation := models.ConvertReservationToModel(reservation)
-
-	tx := s.Database.Begin()
-	if tx.Error != nil {
-		return "", tx.Error
+	err := s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return insertReservation(tx, reservation, tableIDs, maxActive)
+	})
+	if err != nil {
+		return "", err
 	}
+	return reservation.ID, nil
+}
 
+// insertReservation создает бронь и ее связи со столиками в транзакции tx.
+// Если maxActive > 0, у гостя в ресторане не может оказаться больше maxActive действующих броней:
+// брони гостя считаются под advisory-блокировкой на пару гость+ресторан, которая держится
+// до конца транзакции, поэтому параллельные запросы одного гостя не проходят лимит вместе.
+func insertReservation(tx *gorm.DB, reservation *domain.Reservation, tableIDs map[string]string, maxActive int) error {
+	if maxActive > 0 && reservation.UserID != "" {
+		if err := checkActiveReservations(tx, reservation.UserID, reservation.RestaurantID, maxActive); err != nil {
+			return err
+		}
+	}
+	if reservation.Version == 0 {
+		reservation.Version = 1
+	}
+	dbReservation := models.ConvertReservationToModel(reservation)
 	if err := tx.Create(dbReservation).Error; err != nil {
-		tx.Rollback()
-		return "", err
+		return err
 	}
 
 	for key, tableID := range tableIDs {
@@ -235,23 +391,20 @@ func (s *Storage) CreateReservation(reservation *domain.Reservation, tableIDs ma
 		}
 
 		if err := tx.Create(&reservationTable).Error; err != nil {
-			tx.Rollback()
-			return "", err
+			return err
 		}
 	}
-
-	if err := tx.Commit().Error; err != nil {
-		return "", err
-	}
-
-	return dbReservation.ID, nil
+	return nil
 }
 
-func (s *Storage) GetTablesByReservationID(reservationID string) ([]domain.Table, error) {
+func (s *Storage) GetTablesByReservationID(ctx context.Context, reservationID string) ([]domain.Table, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetTablesByReservationID")
+	defer span.End()
 	var tables []models.Table
 
-	// Выполняем запрос к таблице ReservationTable и связываем ее с Table
-	err := s.Database.
+	// Выполняем запрос к таблице ReservationTable и связываем ее с Table. Выведенные из зала
+	// столики тоже возвращаются, чтобы в истории брони было видно, где сидели гости.
+	err := s.Database.WithContext(ctx).Unscoped().
 		Joins("JOIN reservation_tables ON reservation_tables.table_id = tables.id").
 		Where("reservation_tables.reservation_id = ?", reservationID).
 		Find(&tables).Error
@@ -259,7 +412,7 @@ func (s *Storage) GetTablesByReservationID(reservationID string) ([]domain.Table
 	if err != nil {
 		return nil, err
 	}
-	domainTables := make([]domain.Table, len(tables))
+	domainTables := make([]domain.Table, 0, len(tables))
 	for _, table := range tables {
 		domainTable := models.ConvertTableToDomain(&table)
 		domainTables = append(domainTables, *domainTable)
@@ -267,3 +420,93 @@ func (s *Storage) GetTablesByReservationID(reservationID string) ([]domain.Table
 
 	return domainTables, nil
 }
+
+// GetRestaurant возвращает ресторан по его ID.
+func (s *Storage) GetRestaurant(ctx context.Context, restaurantID string) (*domain.Restaurant, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetRestaurant")
+	defer span.End()
+
+	var restaurant models.Restaurant
+	result := s.Database.WithContext(ctx).First(&restaurant, "id = ?", restaurantID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRestaurantNotFound.Withf("restaurant %s not found", restaurantID)
+		}
+		return nil, result.Error
+	}
+	return models.ConvertRestaurantToDomain(&restaurant), nil
+}
+
+// SaveRestaurant создает ресторан или обновляет существующий с тем же ID.
+func (s *Storage) SaveRestaurant(ctx context.Context, restaurant *domain.Restaurant) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveRestaurant")
+	defer span.End()
+
+	dbRestaurant := models.ConvertRestaurantToModel(restaurant)
+	return s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "address", "phone", "timezone"}),
+	}).Create(dbRestaurant).Error
+}
+
+// SaveTable создает столик или обновляет существующий с тем же ID.
+func (s *Storage) SaveTable(ctx context.Context, table *domain.Table) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveTable")
+	defer span.End()
+
+	dbTable := models.ConvertTableToModel(table)
+	return s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"table_number", "capacity", "position_x", "position_y", "position_z"}),
+	}).Create(dbTable).Error
+}
+
+// SaveUser создает пользователя или обновляет существующего с тем же ID.
+func (s *Storage) SaveUser(ctx context.Context, user *domain.User) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveUser")
+	defer span.End()
+
+	dbUser := models.ConvertUserToModel(user)
+	return s.Database.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "phone"}),
+	}).Create(dbUser).Error
+}
+
+// SaveReservation создает бронирование или обновляет существующее с тем же ID.
+// Связи со столиками пересоздаются в той же транзакции.
+func (s *Storage) SaveReservation(ctx context.Context, reservation *domain.Reservation, tableIDs map[string]string) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveReservation")
+	defer span.End()
+
+	if reservation.Version == 0 {
+		reservation.Version = 1
+	}
+	dbReservation := models.ConvertReservationToModel(reservation)
+	updates := clause.AssignmentColumns([]string{"start_time", "end_time", "status", "capacity", "contacts"})
+	updates = append(updates, clause.Assignment{Column: clause.Column{Name: "version"}, Value: gorm.Expr("reservations.version + 1")})
+	return s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: updates,
+		}).Create(dbReservation).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("reservation_id = ?", dbReservation.ID).Delete(&models.ReservationTable{}).Error; err != nil {
+			return err
+		}
+		for key, tableID := range tableIDs {
+			reservationTable := models.ReservationTable{
+				ID:            key,
+				ReservationID: dbReservation.ID,
+				TableID:       tableID,
+			}
+			if err := tx.Create(&reservationTable).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"io"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// newMockStorage возвращает хранилище поверх sqlmock, чтобы проверять запросы без базы.
+func newMockStorage(t *testing.T) (*Storage, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open gorm: %v", err)
+	}
+	return New(slog.New(slog.NewTextHandler(io.Discard, nil)), db), mock
+}
+
+func TestGetTablesByReservationIDReturnsOnlyLinkedTables(t *testing.T) {
+	tests := []struct {
+		name string
+		rows *sqlmock.Rows
+		want []string
+	}{
+		{
+			name: "two tables",
+			rows: sqlmock.NewRows([]string{"id", "restaurant_id", "table_number", "capacity"}).
+				AddRow("t1", "r1", 1, 2).
+				AddRow("t2", "r1", 2, 4),
+			want: []string{"t1", "t2"},
+		},
+		{
+			name: "no tables",
+			rows: sqlmock.NewRows([]string{"id", "restaurant_id", "table_number", "capacity"}),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockStorage(t)
+			mock.ExpectQuery(`SELECT "tables"\."id".* FROM "tables" JOIN reservation_tables ON reservation_tables\.table_id = tables\.id WHERE reservation_tables\.reservation_id = \$1`).
+				WithArgs("res-1").
+				WillReturnRows(tt.rows)
+
+			tables, err := s.GetTablesByReservationID(context.Background(), "res-1")
+			if err != nil {
+				t.Fatalf("GetTablesByReservationID: %v", err)
+			}
+			if len(tables) != len(tt.want) {
+				t.Fatalf("got %d tables, want %d: %+v", len(tables), len(tt.want), tables)
+			}
+			for i, id := range tt.want {
+				if tables[i].ID != id {
+					t.Errorf("tables[%d].ID = %q, want %q", i, tables[i].ID, id)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestUpdateReservationWritesOnlyEditableColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantOK   bool
+	}{
+		{name: "updated", affected: 1, wantOK: true},
+		{name: "stale version", affected: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockStorage(t)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "reservations" SET "canceled_at"=$1,"capacity"=$2,"contacts"=$3,"end_time"=$4,"late_cancel"=$5,"left_at"=$6,"no_show"=$7,"seated_at"=$8,"start_time"=$9,"status"=$10,"version"=version + 1 WHERE (id = $11 AND version = $12) AND "reservations"."deleted_at" IS NULL`)).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectCommit()
+
+			start := time.Date(2026, time.June, 1, 19, 0, 0, 0, time.UTC)
+			reservation := &domain.Reservation{
+				ID:        "res-1",
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				Status:    domain.ReservationConfirmed,
+				Capacity:  3,
+				Version:   4,
+			}
+			ok, err := s.UpdateReservation(context.Background(), reservation)
+			if err != nil {
+				t.Fatalf("UpdateReservation: %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			wantVersion := int64(4)
+			if tt.wantOK {
+				wantVersion = 5
+			}
+			if reservation.Version != wantVersion {
+				t.Errorf("version = %d, want %d", reservation.Version, wantVersion)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCreateReservationEnforcesActiveLimitUnderLock(t *testing.T) {
+	s, mock := newMockStorage(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext($1))`)).
+		WithArgs("reservations/guest-1/restaurant-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "reservations" WHERE (user_id = $1 AND restaurant_id = $2 AND status <> $3 AND end_time > $4)`)).
+		WithArgs("guest-1", "restaurant-1", domain.ReservationCanceled, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	start := time.Now().Add(24 * time.Hour)
+	_, err := s.CreateReservation(context.Background(), &domain.Reservation{
+		ID:           "res-1",
+		UserID:       "guest-1",
+		RestaurantID: "restaurant-1",
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		Status:       domain.ReservationConfirmed,
+		Capacity:     2,
+	}, map[string]string{"link-1": "t1"}, 2)
+	if !errors.Is(err, domain.ErrTooManyReservations) {
+		t.Fatalf("err = %v, want ErrTooManyReservations", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestArchiveReservationsDoesNotTouchAuditLog(t *testing.T) {
+	s, mock := newMockStorage(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "reservations" SET "contacts"=$1,"deleted_at"=$2 WHERE end_time < $3 AND "reservations"."deleted_at" IS NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	now := time.Date(2026, time.June, 1, 3, 0, 0, 0, time.UTC)
+	archived, err := s.ArchiveReservations(context.Background(), now.AddDate(0, 0, -90), now)
+	if err != nil {
+		t.Fatalf("ArchiveReservations: %v", err)
+	}
+	if archived != 3 {
+		t.Errorf("archived = %d, want 3", archived)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// heldTableCondition отбирает действующие предложения листа ожидания, которые придерживают столик.
+// jsonb_exists используется вместо оператора ?, который конфликтует с плейсхолдерами GORM.
+const heldTableCondition = "status = 'offered' AND offer_expires_at > ? AND jsonb_exists(offered_tables, ?)"
+
+// CreateWaitlistEntry добавляет гостя в лист ожидания.
+func (s *Storage) CreateWaitlistEntry(ctx context.Context, entry *domain.WaitlistEntry) error {
+	ctx, span := tracer.Start(ctx, "Storage.CreateWaitlistEntry")
+	defer span.End()
+
+	return s.Database.WithContext(ctx).Create(models.ConvertWaitlistEntryToModel(entry)).Error
+}
+
+// GetWaitlistEntry возвращает запись листа ожидания по ID.
+func (s *Storage) GetWaitlistEntry(ctx context.Context, id string) (*domain.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetWaitlistEntry")
+	defer span.End()
+
+	var entry models.WaitlistEntry
+	result := s.Database.WithContext(ctx).First(&entry, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrWaitlistNotFound
+		}
+		return nil, result.Error
+	}
+	return models.ConvertWaitlistEntryToDomain(&entry), nil
+}
+
+// GetUserWaitlist возвращает записи пользователя в листах ожидания, новые первыми.
+func (s *Storage) GetUserWaitlist(ctx context.Context, userID string) ([]domain.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetUserWaitlist")
+	defer span.End()
+
+	var entries []models.WaitlistEntry
+	err := s.Database.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return convertWaitlist(entries), nil
+}
+
+// GetWaitingEntries возвращает ожидающие записи ресторана, желаемое время которых
+// пересекается с интервалом [from, to), в порядке очереди.
+func (s *Storage) GetWaitingEntries(ctx context.Context, restaurantID string, from, to time.Time) ([]domain.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetWaitingEntries")
+	defer span.End()
+
+	var entries []models.WaitlistEntry
+	err := s.Database.WithContext(ctx).
+		Where("restaurant_id = ? AND status = ? AND start_time < ? AND end_time > ?", restaurantID, domain.WaitlistWaiting, to, from).
+		Order("created_at").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return convertWaitlist(entries), nil
+}
+
+// GetExpiredOffers возвращает предложения, срок принятия которых истек к моменту now.
+func (s *Storage) GetExpiredOffers(ctx context.Context, now time.Time) ([]domain.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetExpiredOffers")
+	defer span.End()
+
+	var entries []models.WaitlistEntry
+	err := s.Database.WithContext(ctx).
+		Where("status = ? AND offer_expires_at <= ?", domain.WaitlistOffered, now).
+		Order("offer_expires_at").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return convertWaitlist(entries), nil
+}
+
+// ExpireStaleWaitlist закрывает ожидающие записи, желаемое время которых уже наступило.
+func (s *Storage) ExpireStaleWaitlist(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := tracer.Start(ctx, "Storage.ExpireStaleWaitlist")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).Model(&models.WaitlistEntry{}).
+		Where("status = ? AND start_time <= ?", domain.WaitlistWaiting, now).
+		Update("status", domain.WaitlistExpired)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateWaitlistEntry сохраняет статус и предложение записи, только если ее текущий
+// статус равен fromStatus. Возвращает false, если запись уже изменил кто-то другой.
+func (s *Storage) UpdateWaitlistEntry(ctx context.Context, entry *domain.WaitlistEntry, fromStatus string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.UpdateWaitlistEntry")
+	defer span.End()
+
+	dbEntry := models.ConvertWaitlistEntryToModel(entry)
+	result := s.Database.WithContext(ctx).Model(&models.WaitlistEntry{}).
+		Where("id = ? AND status = ?", entry.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":           dbEntry.Status,
+			"offered_tables":   dbEntry.OfferedTables,
+			"offer_expires_at": dbEntry.OfferExpiresAt,
+			"reservation_id":   dbEntry.ReservationID,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClaimWaitlistOffer принимает предложение листа ожидания в одной транзакции: создает бронь
+// на столиках tableIDs и закрывает запись entry со ссылкой на нее. Запись и столики блокируются,
+// доступность проверяется с буфером buffer без учета придержки самой записи, поэтому между
+// закрытием предложения и созданием брони столик никто не займет. Если предложение уже
+// принято, отменено или истекло, возвращается ErrNoActiveOffer. maxActive ограничивает число
+// действующих броней гостя в ресторане, как в CreateReservation.
+func (s *Storage) ClaimWaitlistOffer(ctx context.Context, entry *domain.WaitlistEntry, reservation *domain.Reservation, tableIDs map[string]string, buffer time.Duration, maxActive int) error {
+	ctx, span := tracer.Start(ctx, "Storage.ClaimWaitlistOffer")
+	defer span.End()
+
+	ids := make([]string, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		ids = append(ids, tableID)
+	}
+	err := s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var offer models.WaitlistEntry
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND offer_expires_at > ?", entry.ID, domain.WaitlistOffered, time.Now()).
+			First(&offer).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrNoActiveOffer
+		}
+		if err != nil {
+			return err
+		}
+
+		var locked []models.Table
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Order("id").Find(&locked).Error; err != nil {
+			return err
+		}
+		for _, tableID := range ids {
+			ok, err := tableAvailable(tx, tableID, reservation.StartTime.Add(-buffer), reservation.EndTime.Add(buffer), "", entry.ID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return domain.ErrTableNotAvailable.Withf("table %s not available", tableID)
+			}
+		}
+
+		if err := insertReservation(tx, reservation, tableIDs, maxActive); err != nil {
+			return err
+		}
+		return tx.Model(&models.WaitlistEntry{}).
+			Where("id = ?", entry.ID).
+			Updates(map[string]interface{}{
+				"status":         domain.WaitlistClaimed,
+				"reservation_id": reservation.ID,
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+	entry.Status = domain.WaitlistClaimed
+	entry.ReservationID = reservation.ID
+	return nil
+}
+
+// GetRestaurantTables возвращает все столики ресторана.
+func (s *Storage) GetRestaurantTables(ctx context.Context, restaurantID string) ([]domain.Table, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetRestaurantTables")
+	defer span.End()
+
+	var dbTables []models.Table
+	err := s.Database.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Order("table_number").Find(&dbTables).Error
+	if err != nil {
+		return nil, err
+	}
+	tables := make([]domain.Table, 0, len(dbTables))
+	for _, t := range dbTables {
+		tables = append(tables, *models.ConvertTableToDomain(&t))
+	}
+	return tables, nil
+}
+
+func convertWaitlist(entries []models.WaitlistEntry) []domain.WaitlistEntry {
+	result := make([]domain.WaitlistEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, *models.ConvertWaitlistEntryToDomain(&e))
+	}
+	return result
+}
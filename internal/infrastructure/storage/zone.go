@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"booking_system/internal/domain"
+	"booking_system/internal/infrastructure/storage/models"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// SaveZone создает зону или заменяет существующую вместе с ее столиками.
+func (s *Storage) SaveZone(ctx context.Context, zone *domain.Zone) error {
+	ctx, span := tracer.Start(ctx, "Storage.SaveZone")
+	defer span.End()
+
+	dbZone := models.ConvertZoneToModel(zone)
+	return s.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "min_duration_minutes", "max_duration_minutes", "requires_approval"}),
+		}).Create(dbZone).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("zone_id = ?", dbZone.ID).Delete(&models.ZoneTable{}).Error; err != nil {
+			return err
+		}
+		if len(dbZone.Tables) == 0 {
+			return nil
+		}
+		return tx.Create(&dbZone.Tables).Error
+	})
+}
+
+// GetZone возвращает зону ресторана по ID.
+func (s *Storage) GetZone(ctx context.Context, restaurantID string, zoneID string) (*domain.Zone, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetZone")
+	defer span.End()
+
+	var zone models.Zone
+	result := s.Database.WithContext(ctx).Preload("Tables").
+		First(&zone, "id = ? AND restaurant_id = ?", zoneID, restaurantID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrZoneNotFound
+		}
+		return nil, result.Error
+	}
+	return models.ConvertZoneToDomain(&zone), nil
+}
+
+// GetZones возвращает зоны ресторана в алфавитном порядке.
+func (s *Storage) GetZones(ctx context.Context, restaurantID string) ([]domain.Zone, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetZones")
+	defer span.End()
+
+	var zones []models.Zone
+	err := s.Database.WithContext(ctx).Preload("Tables").
+		Where("restaurant_id = ?", restaurantID).Order("name").Find(&zones).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Zone, 0, len(zones))
+	for i := range zones {
+		result = append(result, *models.ConvertZoneToDomain(&zones[i]))
+	}
+	return result, nil
+}
+
+// DeleteZone удаляет зону. Возвращает false, если зона не найдена.
+func (s *Storage) DeleteZone(ctx context.Context, restaurantID string, zoneID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Storage.DeleteZone")
+	defer span.End()
+
+	result := s.Database.WithContext(ctx).Delete(&models.Zone{}, "id = ? AND restaurant_id = ?", zoneID, restaurantID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetPendingEventReservations возвращает брони зон и выкупы ресторана, ожидающие подтверждения.
+func (s *Storage) GetPendingEventReservations(ctx context.Context, restaurantID string) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetPendingEventReservations")
+	defer span.End()
+
+	var dbReservations []models.Reservation
+	err := s.Database.WithContext(ctx).
+		Where("restaurant_id = ? AND scope <> ? AND status = ?", restaurantID, domain.ReservationScopeTables, domain.ReservationWait).
+		Order("start_time").
+		Find(&dbReservations).Error
+	if err != nil {
+		return nil, err
+	}
+	reservations := make([]*domain.Reservation, 0, len(dbReservations))
+	for i := range dbReservations {
+		reservations = append(reservations, models.ConvertReservationToDomain(&dbReservations[i]))
+	}
+	return reservations, nil
+}
+
+// GetExpiredApprovals возвращает брони зон и выкупы, которые менеджер не подтвердил к now.
+func (s *Storage) GetExpiredApprovals(ctx context.Context, now time.Time) ([]*domain.Reservation, error) {
+	ctx, span := tracer.Start(ctx, "Storage.GetExpiredApprovals")
+	defer span.End()
+
+	var dbReservations []models.Reservation
+	err := s.Database.WithContext(ctx).
+		Where("status = ? AND approval_expires_at <= ?", domain.ReservationWait, now).
+		Find(&dbReservations).Error
+	if err != nil {
+		return nil, err
+	}
+	reservations := make([]*domain.Reservation, 0, len(dbReservations))
+	for i := range dbReservations {
+		reservations = append(reservations, models.ConvertReservationToDomain(&dbReservations[i]))
+	}
+	return reservations, nil
+}